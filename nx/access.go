@@ -0,0 +1,66 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// formatAccessBody - formats the json body of add/replace operations
+// for setting the access vlan of interface. Empty vlan restores vlan 1.
+// tag and id are the object class and id returned by interfaceID.
+func (c *Client) formatAccessBody(tag string, id string, vlan string) string {
+	if vlan == "" {
+		vlan = "1"
+	}
+
+	intf := NewMO(tag).Set("id", id).Set("mode", AccessMode).Set("accessVlan", "vlan-"+vlan)
+
+	return configBody(NewMO("interfaceEntity").AddChild(intf))
+}
+
+// SetAccessVlan - Sets interface to access mode in vlan
+func (c *Client) SetAccessVlan(ifName string, vlan string) error {
+	return c.SetAccessVlanContext(context.Background(), ifName, vlan)
+}
+
+// SetAccessVlanContext is like SetAccessVlan but honors ctx for cancellation and deadline.
+func (c *Client) SetAccessVlanContext(ctx context.Context, ifName string, vlan string) error {
+
+	if n, errConv := strconv.Atoi(vlan); errConv != nil || n < MinVlan || n > MaxVlan {
+		return fmt.Errorf("bad access vlan '%s': expected %d-%d", vlan, MinVlan, MaxVlan)
+	}
+
+	return c.postAccessVlan(ctx, ifName, vlan)
+}
+
+// ClearAccessVlan - Sets interface to access mode in default vlan 1
+func (c *Client) ClearAccessVlan(ifName string) error {
+	return c.ClearAccessVlanContext(context.Background(), ifName)
+}
+
+// ClearAccessVlanContext is like ClearAccessVlan but honors ctx for cancellation and deadline.
+func (c *Client) ClearAccessVlanContext(ctx context.Context, ifName string) error {
+	return c.postAccessVlan(ctx, ifName, "")
+}
+
+func (c *Client) postAccessVlan(ctx context.Context, ifName string, vlan string) error {
+
+	tag, id, err := c.interfaceID(ifName)
+	if err != nil {
+		return err
+	}
+
+	jsonAccess := c.formatAccessBody(tag, id, vlan)
+
+	c.debugf("access vlan set: Body=%s", jsonAccess)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonAccess))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
@@ -4,8 +4,32 @@ const (
     // Single URI Definition for Add, Replace, interface Delete attributes
     ConfigRootURI = "/api/mo.json"
 
+    // URI Definition for Get, Add, Delete of any object
+    // Where %s is the object distinguished name. Ex: sys/bd/bd-[vlan-10]
+    DnURI = "/api/mo/%s.json"
+    // Where %s is the object class. Ex: l2BD
+    ClassURI = "/api/class/%s.json"
+
+    // Query targets of QueryOptions
+    QueryTargetSelf = "self"
+    QueryTargetChildren = "children"
+    QueryTargetSubtree = "subtree"
+
+    // Response subtrees of QueryOptions
+    RspSubtreeNo = "no"
+    RspSubtreeChildren = "children"
+    RspSubtreeFull = "full"
+
+    // Response properties of QueryOptions
+    RspPropAll = "all"
+    RspPropNamingOnly = "naming-only"
+    RspPropConfigOnly = "config-only"
+
     // Body definitions Start
+    //
+    // Deprecated: build bodies with MO, which escapes values.
     TopBegin = `{"topSystem": { "children": [ `
+    // Deprecated: build bodies with MO, which escapes values.
     TopEnd = `]}}`
 
     // URI Definition for Get
@@ -16,8 +40,17 @@ const (
     InterfaceEnetURI = "/api/mo/sys/intf/phys-[eth%s].json"
     InterfacePcURI = "/api/mo/sys/intf/aggr-[po%s].json"
 
+    // URI Definition for Get of interface operational status and counters
+    // Where %s is interface id. Ex: 1/12 for ethernet or 55 for port-channel
+    InterfaceEnetStatusURI = "/api/mo/sys/intf/phys-[eth%s]/phys.json"
+    InterfacePcStatusURI = "/api/mo/sys/intf/aggr-[po%s]/aggrif.json"
+    InterfaceEnetCountersURI = "/api/mo/sys/intf/phys-[eth%s].json?query-target=children&target-subtree-class=rmonIfIn,rmonIfOut"
+    InterfacePcCountersURI = "/api/mo/sys/intf/aggr-[po%s].json?query-target=children&target-subtree-class=rmonIfIn,rmonIfOut"
+
     // switchport mode <which-mode> Where id is interface-id ex: po5 eth1/3
     // modes are trunk, access, or edge
+    //
+    // Deprecated: build bodies with MO, which escapes values.
     SwitchPortMode = `{ "stpEntity": { "children": [ {
                      "stpInst": { "children": [ { "stpIf": {
                      "attributes": { "id": "%s",
@@ -26,39 +59,79 @@ const (
     AccessMode = "access"
     EdgeMode = "edge"
 
+    // URI Definition for Get switchport mode
+    // Where %s is interface-id ex: po5 eth1/3
+    StpIfURI = "/api/mo/sys/stp/inst/if-[%s].json"
+
     // switchport trunk native vlan 129
     // switchport trunk allowed vlan 129,136
     // 1st %s is pcAggrIf for Port channel and l1PhysIf for ethernet
     // 2nd&3rd %s is interface-id ex: po5 eth1/3
     // 4th %s is TrunkMode (above)
     // 5th %s is trunkVlans and nativeVlan config (below)
+    //
+    // Deprecated: build bodies with MO, which escapes values.
     IfEntity = `{ "interfaceEntity": { "children": [ { "%s": { "attributes": { "id": "%s%s", "mode": "%s", %s } } } ] } }`
+
+    // Interface admin states
+    AdminUp = "up"
+    AdminDown = "down"
+
     PcTag = "pcAggrIf"
     EnetTag = "l1PhysIf"
     PcPfx = "po"
     EnetPfx = "eth"
+    // Attribute templates of IfEntity.
+    //
+    // Deprecated: build bodies with MO, which escapes values.
     NativeVlan = `"nativeVlan": "vlan-%s"`
+    // Deprecated: build bodies with MO, which escapes values.
     NoNativeVlan = `"nativeVlan": ""`
+    // Deprecated: build bodies with MO, which escapes values.
     TrunkVlans = `"trunkVlans": "%s"`
 
-    //  Creates vpc on the Port Channel itself
-    //    int port-channel 19
-    //        vpc 19
-    vpcEntity = `{ "vpcEntity": { "children": [ { "vpcInst": {
-                 "children": [ { "vpcDom": {
-                 "children": [ { "vpcIf": {
-                 "attributes": { "id": "%s" },
-                 "children": [ { "vpcRsVpcConf": {
-                 "attributes": { "tDn": "sys/intf/aggr-[po%s]"
-                 } } } ] } } ] } } ] } } ] } }`
+    // Port channel modes
+    // channel-group 5 mode <which-mode>
+    PcModeActive = "active"
+    PcModePassive = "passive"
+    PcModeOn = "on"
+
+    // URI Definition for Delete of port channel member
+    // 1st %s is port-channel id and 2nd %s ethernet id. Ex: 5 1/3
+    PcMbrURI = "/api/mo/sys/intf/aggr-[po%s]/rsmbrIfs-[sys/intf/phys-[eth%s]].json"
+
+    // LACP rates
+    // lacp rate <which-rate>
+    LacpRateFast = "fast"
+    LacpRateNormal = "normal"
+
+    // URI Definition for Get of port channel members
+    // Where %s is port-channel id
+    PcMbrAllURI = "/api/mo/sys/intf/aggr-[po%s].json?query-target=children&target-subtree-class=pcRsMbrIfs"
+    AllPcMbrURI = "/api/mo/sys/intf.json?query-target=subtree&target-subtree-class=pcRsMbrIfs"
+
+    // URI Definition for Get of vpc on Port Channel
+    // Where %s is the vpc id
+    VpcIfURI = "/api/mo/sys/vpc/inst/dom/if-%s.json?rsp-subtree=children"
+    // URI Definition for Delete of vpc on Port Channel
+    // Where %s is the vpc id
+    VpcIfDnURI = "/api/mo/sys/vpc/inst/dom/if-%s.json"
+    AllVpcIfURI = "/api/mo/sys/vpc/inst/dom.json?query-target=children&target-subtree-class=vpcIf&rsp-subtree=children"
+
+    // URI Definition for Get, Delete of vpc domain
+    VpcDomURI = "/api/mo/sys/vpc/inst/dom.json"
+    VpcDomSubtreeURI = "/api/mo/sys/vpc/inst/dom.json?rsp-subtree=children"
 
     // URI Definition for Get, Delete
     VlanURI = `/api/mo/sys/bd/bd-[vlan-%s].json`
     AllVlanURI = `/api/mo/sys/bd/.json?query-target=subtree&target-subtree-class=l2BD`
 
-    // VLAN Body for add operation
-    vlanEntity = `{ "bdEntity": { "children": [ {"l2BD": {"attributes": {"fabEncap": "vlan-%s", "pcTag": "1", "adminSt": "active"%s } } } ] } }`
-    vxlanSegment = `, "accEncap": "vxlan-%s"`
+    // VLAN admin states and modes
+    VlanActive = "active"
+    VlanSuspend = "suspend"
+    VlanModeCE = "CE"
+    VlanModeFabricPath = "FabricPath"
+    NoVni = "none"
 
 )
 
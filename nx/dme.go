@@ -0,0 +1,144 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetDN returns the objects selected by q at distinguished name dn
+// (Ex: sys/bd/bd-[vlan-10]). A missing dn returns no object.
+func (c *Client) GetDN(dn string, q QueryOptions) ([]*MO, error) {
+	return c.GetDNContext(context.Background(), dn, q)
+}
+
+// GetDNContext is like GetDN but honors ctx for cancellation and deadline.
+func (c *Client) GetDNContext(ctx context.Context, dn string, q QueryOptions) ([]*MO, error) {
+	if strings.Trim(dn, "/") == "" {
+		return nil, fmt.Errorf("missing dn")
+	}
+	return c.getMOs(ctx, fmt.Sprintf(DnURI, strings.Trim(dn, "/")), q)
+}
+
+// GetClass returns the objects of class (Ex: l1PhysIf) found anywhere in
+// the tree, with the children selected by q.
+func (c *Client) GetClass(class string, q QueryOptions) ([]*MO, error) {
+	return c.GetClassContext(context.Background(), class, q)
+}
+
+// GetClassContext is like GetClass but honors ctx for cancellation and deadline.
+func (c *Client) GetClassContext(ctx context.Context, class string, q QueryOptions) ([]*MO, error) {
+	if class == "" {
+		return nil, fmt.Errorf("missing class")
+	}
+	return c.getMOs(ctx, fmt.Sprintf(ClassURI, class), q)
+}
+
+// maxPages bounds the pages requested when QueryOptions.MaxPages is 0,
+// against a switch returning full pages forever.
+const maxPages = 1000
+
+// getMOs queries uri, requesting successive pages when q.PageSize is set
+// until a short page, the reply totalCount or q.MaxPages is reached.
+// A page starting with the same object as the previous one ends the query
+// too, as a switch ignoring paging returns the same objects for every page.
+func (c *Client) getMOs(ctx context.Context, uri string, q QueryOptions) ([]*MO, error) {
+
+	var result []*MO
+	prevFirst := ""
+
+	for page := q.Page; q.MaxPages == 0 || page < q.Page+q.MaxPages; page++ {
+		if q.MaxPages == 0 && page-q.Page >= maxPages {
+			return nil, fmt.Errorf("Unexpected paging: no last page after %d pages of %d objects", maxPages, q.PageSize)
+		}
+
+		query, errQuery := q.query(page)
+		if errQuery != nil {
+			return nil, errQuery
+		}
+
+		body, errGet := c.get(ctx, uri+query)
+		if errGet != nil {
+			return nil, errGet
+		}
+
+		list, total, errParse := parseImdataPage(body)
+		if errParse != nil {
+			return nil, errParse
+		}
+		if len(list) == 0 {
+			break
+		}
+		first := list[0].Attr("dn")
+		if first != "" && first == prevFirst {
+			break // paging ignored: same page again
+		}
+		prevFirst = first
+		result = append(result, list...)
+
+		if q.PageSize == 0 || len(list) < q.PageSize || (total > 0 && (page+1)*q.PageSize >= total) {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// PostMO creates or updates object mo and its children.
+// An object holding a dn attribute is posted at that dn; otherwise mo is
+// posted at the top of the tree, wrapped into topSystem unless mo already
+// is a topSystem.
+// Ex: vlan 10
+//
+//	c.PostMO(NewMO("bdEntity").AddChild(NewMO("l2BD").Set("fabEncap", "vlan-10")))
+func (c *Client) PostMO(mo *MO) error {
+	return c.PostMOContext(context.Background(), mo)
+}
+
+// PostMOContext is like PostMO but honors ctx for cancellation and deadline.
+func (c *Client) PostMOContext(ctx context.Context, mo *MO) error {
+
+	if mo == nil || mo.Class == "" {
+		return fmt.Errorf("missing object class")
+	}
+
+	uri := ConfigRootURI
+	jsonMO := mo.String()
+	switch {
+	case strings.Trim(mo.Attr("dn"), "/") != "":
+		uri = fmt.Sprintf(DnURI, strings.Trim(mo.Attr("dn"), "/"))
+	case mo.Class != "topSystem":
+		jsonMO = configBody(mo)
+	}
+
+	c.debugf("mo post: uri=%s Body=%s", uri, jsonMO)
+
+	body, errPost := c.post(ctx, uri, contentTypeJSON, bytes.NewBufferString(jsonMO))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// DeleteDN removes the object at distinguished name dn (Ex: sys/bd/bd-[vlan-10])
+// and its subtree.
+func (c *Client) DeleteDN(dn string) error {
+	return c.DeleteDNContext(context.Background(), dn)
+}
+
+// DeleteDNContext is like DeleteDN but honors ctx for cancellation and deadline.
+func (c *Client) DeleteDNContext(ctx context.Context, dn string) error {
+
+	if strings.Trim(dn, "/") == "" {
+		return fmt.Errorf("missing dn")
+	}
+
+	body, errDel := c.delete(ctx, fmt.Sprintf(DnURI, strings.Trim(dn, "/")))
+	if errDel != nil {
+		return errDel
+	}
+
+	return parseJSONError(body)
+}
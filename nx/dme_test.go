@@ -0,0 +1,169 @@
+package nx_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+func TestGetClassPaging(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	// eth1/1 to eth1/7, odd ones admin up.
+	for i := 1; i <= 7; i++ {
+		adminSt := nx.AdminDown
+		if i%2 == 1 {
+			adminSt = nx.AdminUp
+		}
+		srv.AddMO(fmt.Sprintf("sys/intf/phys-[eth1/%d]", i), "l1PhysIf", map[string]string{
+			"id":      fmt.Sprintf("eth1/%d", i),
+			"adminSt": adminSt,
+		})
+	}
+
+	c, errNew := nx.New(srv.ClientOptions())
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+
+	tests := []struct {
+		q        nx.QueryOptions
+		want     string
+		requests int
+	}{
+		{q: nx.QueryOptions{}, want: "1,2,3,4,5,6,7", requests: 1},
+		{q: nx.QueryOptions{PageSize: 3}, want: "1,2,3,4,5,6,7", requests: 3},
+		{q: nx.QueryOptions{PageSize: 7}, want: "1,2,3,4,5,6,7", requests: 1},
+		{q: nx.QueryOptions{PageSize: 2, Page: 1, MaxPages: 2}, want: "3,4,5,6", requests: 2},
+		{q: nx.QueryOptions{PageSize: 2, Filter: nx.Eq("l1PhysIf.adminSt", nx.AdminUp)}, want: "1,3,5,7", requests: 2},
+		{q: nx.QueryOptions{PageSize: 5, OrderBy: []string{"l1PhysIf.id|desc"}}, want: "7,6,5,4,3,2,1", requests: 2},
+		{q: nx.QueryOptions{Filter: nx.Or(nx.Eq("l1PhysIf.id", "eth1/2"), nx.Wcard("l1PhysIf.id", "/[67]$"))}, want: "2,6,7", requests: 1},
+	}
+
+	for _, tt := range tests {
+		before := len(srv.Requests())
+		list, errGet := c.GetClass("l1PhysIf", tt.q)
+		if errGet != nil {
+			t.Errorf("%+v: %v", tt.q, errGet)
+			continue
+		}
+		var ids []string
+		for _, m := range list {
+			ids = append(ids, strings.TrimPrefix(m.Attr("id"), "eth1/"))
+		}
+		if got := strings.Join(ids, ","); got != tt.want {
+			t.Errorf("%+v: got %s, want %s", tt.q, got, tt.want)
+		}
+		if got := len(srv.Requests()) - before; got != tt.requests {
+			t.Errorf("%+v: sent %d requests, want %d", tt.q, got, tt.requests)
+		}
+	}
+}
+
+// noPaging makes the switch behind next ignore paging: page and page-size
+// are dropped from queries and totalCount from replies. With endless set,
+// class queries get instead a full page of new objects every time.
+type noPaging struct {
+	next    http.RoundTripper
+	endless bool
+}
+
+func (p noPaging) RoundTrip(req *http.Request) (*http.Response, error) {
+	q := req.URL.Query()
+	page, size := q.Get("page"), q.Get("page-size")
+	q.Del("page")
+	q.Del("page-size")
+	req.URL.RawQuery = q.Encode()
+
+	if p.endless && strings.HasPrefix(req.URL.Path, "/api/class/") {
+		var list []string
+		for i := 0; i < 2; i++ {
+			list = append(list, fmt.Sprintf(`{"l1PhysIf":{"attributes":{"dn":"sys/intf/phys-[eth%s/%s%d]"}}}`, page, size, i))
+		}
+		body := `{"imdata":[` + strings.Join(list, ",") + `]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+
+	resp, errTrip := p.next.RoundTrip(req)
+	if errTrip != nil {
+		return nil, errTrip
+	}
+	body, errRead := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if errRead != nil {
+		return nil, errRead
+	}
+	var reply map[string]interface{}
+	if json.Unmarshal(body, &reply) == nil && reply["totalCount"] != nil {
+		delete(reply, "totalCount")
+		body, _ = json.Marshal(reply)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+func TestGetClassPagingIgnored(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	for i := 1; i <= 5; i++ {
+		srv.AddMO(fmt.Sprintf("sys/intf/phys-[eth1/%d]", i), "l1PhysIf", map[string]string{"id": fmt.Sprintf("eth1/%d", i)})
+	}
+
+	opt := srv.ClientOptions()
+	opt.Transport = noPaging{next: nx.NewTransport(opt)}
+	c, errNew := nx.New(opt)
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+
+	// Every page holds all 5 objects: the second one repeats the first.
+	before := len(srv.Requests())
+	list, errGet := c.GetClass("l1PhysIf", nx.QueryOptions{PageSize: 2})
+	if errGet != nil {
+		t.Fatalf("GetClass: %v", errGet)
+	}
+	if len(list) != 5 {
+		t.Errorf("GetClass: got %d objects, want 5", len(list))
+	}
+	if got := len(srv.Requests()) - before; got != 2 {
+		t.Errorf("GetClass: sent %d requests, want 2", got)
+	}
+
+	// A switch returning new full pages forever hits the page limit.
+	opt.Transport = noPaging{next: nx.NewTransport(opt), endless: true}
+	endless, errNew := nx.New(opt)
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := endless.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	if list, errGet := endless.GetClass("l1PhysIf", nx.QueryOptions{PageSize: 2}); errGet == nil {
+		t.Errorf("GetClass on endless pages: got %d objects, want error", len(list))
+	}
+	if list, errGet := endless.GetClass("l1PhysIf", nx.QueryOptions{PageSize: 2, MaxPages: 3}); errGet != nil || len(list) != 6 {
+		t.Errorf("GetClass on endless pages with MaxPages 3: got %d objects, %v; want 6", len(list), errGet)
+	}
+}
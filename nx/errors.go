@@ -0,0 +1,126 @@
+package nx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// tokenExpiredPrefix starts the imdata error text of NX-API replies refusing
+// an expired or invalidated session token, followed by the reason.
+// Ex: Token was invalid (Error: Token timeout)
+const tokenExpiredPrefix = "Token was invalid"
+
+// Error is returned by Client methods when the Nexus switch rejects a request,
+// either with an HTTP error status or with an error object in the imdata reply.
+type Error struct {
+	Code       string // imdata error code. Ex: "403". Empty if the reply carried no imdata error.
+	Text       string // imdata error text, or HTTP status text if the reply carried no imdata error.
+	StatusCode int    // HTTP status code. Zero if the error was parsed from a reply body alone.
+	Method     string // HTTP method of the failed request. Ex: "POST"
+	URI        string // URL of the failed request
+}
+
+func (e *Error) Error() string {
+	if e.Method == "" {
+		return fmt.Sprintf("error: code=%s text=%s", e.Code, e.Text)
+	}
+	return fmt.Sprintf("%s %s: error: status=%d code=%s text=%s", e.Method, e.URI, e.StatusCode, e.Code, e.Text)
+}
+
+// IsNotFound reports whether err means the requested object does not exist.
+func IsNotFound(err error) bool {
+	e, isErr := asError(err)
+	if !isErr {
+		return false
+	}
+	return e.StatusCode == http.StatusNotFound || e.Code == "404"
+}
+
+// IsAuthError reports whether err means the request was refused because of
+// bad credentials, an expired or invalid token, or missing privileges.
+func IsAuthError(err error) bool {
+	e, isErr := asError(err)
+	if !isErr {
+		return false
+	}
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden ||
+		e.Code == "401" || e.Code == "403"
+}
+
+// IsConflict reports whether err means the request conflicts with existing configuration.
+func IsConflict(err error) bool {
+	e, isErr := asError(err)
+	if !isErr {
+		return false
+	}
+	return e.StatusCode == http.StatusConflict || e.Code == "409"
+}
+
+func asError(err error) (*Error, bool) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil, false
+	}
+	return e, true
+}
+
+// newImdataError builds an Error from the attributes of an imdata error object.
+func newImdataError(e interface{}) *Error {
+	attr := mapSimple(e, "attributes")
+	return &Error{Code: mapString(attr, "code"), Text: mapString(attr, "text")}
+}
+
+// findImdataError returns the error object carried by the first imdata member, if any.
+func findImdataError(body []byte) *Error {
+	var reply interface{}
+	if errJSON := json.Unmarshal(body, &reply); errJSON != nil {
+		return nil
+	}
+	imdata, errImdata := mapGet(reply, "imdata")
+	if errImdata != nil {
+		return nil
+	}
+	first, errFirst := sliceGet(imdata, 0)
+	if errFirst != nil {
+		return nil
+	}
+	e, errErr := mapGet(first, "error")
+	if errErr != nil {
+		return nil
+	}
+	return newImdataError(e)
+}
+
+// replyError checks an HTTP reply for failure status or imdata error.
+// It returns nil for a successful reply.
+func replyError(method, url string, statusCode int, body []byte) error {
+	e := findImdataError(body)
+	if e == nil {
+		if statusCode >= 200 && statusCode < 300 {
+			return nil // ok
+		}
+		e = &Error{Text: http.StatusText(statusCode)}
+	}
+	e.StatusCode = statusCode
+	e.Method = method
+	e.URI = url
+	return e
+}
+
+// isTokenExpired reports whether err means the session token is no longer valid,
+// so that a new Login is expected to succeed.
+func isTokenExpired(err error) bool {
+	e, isErr := asError(err)
+	if !isErr || !IsAuthError(err) {
+		return false
+	}
+	return strings.HasPrefix(e.Text, tokenExpiredPrefix)
+}
+
+// notFoundError reports an object missing from an otherwise successful reply.
+func notFoundError(format string, v ...interface{}) *Error {
+	return &Error{Code: "404", Text: fmt.Sprintf(format, v...)}
+}
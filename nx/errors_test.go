@@ -0,0 +1,42 @@
+package nx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		err                               error
+		notFound, auth, conflict, expired bool
+	}{
+		{err: fmt.Errorf("dial tcp: timeout")},
+		{err: &Error{StatusCode: 404, Text: "Not Found"}, notFound: true},
+		{err: notFoundError("vlan %s not found", "10"), notFound: true},
+		{err: &Error{StatusCode: 400, Code: "1", Text: "parent of sys/bd/bd-[vlan-10] not found"}},
+		{err: &Error{StatusCode: 400, Code: "107", Text: "property fabEncap of l2BD does not exist"}},
+		{err: &Error{StatusCode: 401, Code: "401", Text: "Username or password is incorrect"}, auth: true},
+		{err: &Error{StatusCode: 403, Code: "403", Text: "Token was invalid (Error: Token timeout)"}, auth: true, expired: true},
+		{err: &Error{StatusCode: 403, Code: "403", Text: "Token was invalid (Error: Token expired)"}, auth: true, expired: true},
+		{err: &Error{StatusCode: 403, Code: "403", Text: "user does not have write privilege"}, auth: true},
+		{err: &Error{StatusCode: 400, Code: "1", Text: "Token was invalid"}},
+		{err: &Error{StatusCode: 409, Text: "Conflict"}, conflict: true},
+		{err: &Error{StatusCode: 400, Code: "1", Text: "vlan 10 conflicts with reserved vlans; unauthorized"}},
+		{err: fmt.Errorf("add vlan: %w", &Error{StatusCode: 404}), notFound: true},
+	}
+
+	for _, tt := range tests {
+		if got := IsNotFound(tt.err); got != tt.notFound {
+			t.Errorf("IsNotFound(%v) = %v", tt.err, got)
+		}
+		if got := IsAuthError(tt.err); got != tt.auth {
+			t.Errorf("IsAuthError(%v) = %v", tt.err, got)
+		}
+		if got := IsConflict(tt.err); got != tt.conflict {
+			t.Errorf("IsConflict(%v) = %v", tt.err, got)
+		}
+		if got := isTokenExpired(tt.err); got != tt.expired {
+			t.Errorf("isTokenExpired(%v) = %v", tt.err, got)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package nx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Fleet runs the same operation concurrently on many Nexus switches,
+// each reached through its own Client.
+// The zero value is an empty Fleet without parallelism limit.
+type Fleet struct {
+	Parallelism int // Maximum number of switches operated at once. Zero or less means no limit.
+
+	names   []string
+	clients map[string]*Client
+}
+
+// FleetResult is the outcome of a Fleet operation on a single switch.
+type FleetResult struct {
+	Switch     string                   // Switch name given to Fleet.Add
+	Err        error                    // Nil if the operation succeeded on the switch
+	Interfaces []map[string]interface{} // Reply for GetInterface. Nil for other operations.
+}
+
+// FleetReport holds one FleetResult per switch, in the order switches were added.
+type FleetReport []FleetResult
+
+// NewFleet creates an empty Fleet operating at most parallelism switches at once.
+func NewFleet(parallelism int) *Fleet {
+	return &Fleet{Parallelism: parallelism, clients: map[string]*Client{}}
+}
+
+// Add registers Client c under the switch name used in reports.
+// Adding a name twice replaces the previous Client.
+func (f *Fleet) Add(name string, c *Client) {
+	if f.clients == nil {
+		f.clients = map[string]*Client{}
+	}
+	if _, found := f.clients[name]; !found {
+		f.names = append(f.names, name)
+	}
+	f.clients[name] = c
+}
+
+// Client returns the Client registered under name, or nil.
+func (f *Fleet) Client(name string) *Client {
+	return f.clients[name]
+}
+
+// Switches returns the switch names in the order they were added.
+func (f *Fleet) Switches() []string {
+	return append([]string(nil), f.names...)
+}
+
+// Failed returns the results of switches where the operation failed.
+func (r FleetReport) Failed() []FleetResult {
+	var failed []FleetResult
+	for _, res := range r {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err summarizes the failed switches in a single error, or returns nil if all succeeded.
+func (r FleetReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, res := range failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", res.Switch, res.Err))
+	}
+	return fmt.Errorf("%d of %d switches failed: %s", len(failed), len(r), strings.Join(msgs, "; "))
+}
+
+// run calls op for every switch, at most f.Parallelism at once.
+func (f *Fleet) run(ctx context.Context, op func(ctx context.Context, c *Client, res *FleetResult)) FleetReport {
+	report := make(FleetReport, len(f.names))
+
+	limit := f.Parallelism
+	if limit <= 0 || limit > len(f.names) {
+		limit = len(f.names)
+	}
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, name := range f.names {
+		report[i].Switch = name
+		wg.Add(1)
+		go func(res *FleetResult, c *Client) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				res.Err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			op(ctx, c, res)
+		}(&report[i], f.clients[name])
+	}
+	wg.Wait()
+
+	return report
+}
+
+// Login opens a session on every switch.
+func (f *Fleet) Login() FleetReport {
+	return f.LoginContext(context.Background())
+}
+
+// LoginContext is like Login but honors ctx for cancellation and deadline.
+func (f *Fleet) LoginContext(ctx context.Context) FleetReport {
+	return f.run(ctx, func(ctx context.Context, c *Client, res *FleetResult) {
+		res.Err = c.LoginContext(ctx)
+	})
+}
+
+// Close stops keepalive and closes the session on every switch.
+func (f *Fleet) Close() FleetReport {
+	return f.run(context.Background(), func(ctx context.Context, c *Client, res *FleetResult) {
+		res.Err = c.Close()
+	})
+}
+
+// AddVlan creates vlan vlanId, optionally mapped to VxLAN segment vni, on every switch.
+func (f *Fleet) AddVlan(vlanId string, vni string) FleetReport {
+	return f.AddVlanContext(context.Background(), vlanId, vni)
+}
+
+// AddVlanContext is like AddVlan but honors ctx for cancellation and deadline.
+func (f *Fleet) AddVlanContext(ctx context.Context, vlanId string, vni string) FleetReport {
+	return f.run(ctx, func(ctx context.Context, c *Client, res *FleetResult) {
+		res.Err = c.AddVlanContext(ctx, vlanId, vni)
+	})
+}
+
+// DeleteVlan removes vlan id from every switch.
+func (f *Fleet) DeleteVlan(id string) FleetReport {
+	return f.DeleteVlanContext(context.Background(), id)
+}
+
+// DeleteVlanContext is like DeleteVlan but honors ctx for cancellation and deadline.
+func (f *Fleet) DeleteVlanContext(ctx context.Context, id string) FleetReport {
+	return f.run(ctx, func(ctx context.Context, c *Client, res *FleetResult) {
+		res.Err = c.DeleteVlanContext(ctx, id)
+	})
+}
+
+// AddTrunkVlan adds trunk/native vlans to interface ifName on every switch.
+func (f *Fleet) AddTrunkVlan(ifName string, allowed string, native string) FleetReport {
+	return f.AddTrunkVlanContext(context.Background(), ifName, allowed, native)
+}
+
+// AddTrunkVlanContext is like AddTrunkVlan but honors ctx for cancellation and deadline.
+func (f *Fleet) AddTrunkVlanContext(ctx context.Context, ifName string, allowed string, native string) FleetReport {
+	return f.run(ctx, func(ctx context.Context, c *Client, res *FleetResult) {
+		res.Err = c.AddTrunkVlanContext(ctx, ifName, allowed, native)
+	})
+}
+
+// GetInterface reads interface ifName from every switch into FleetResult.Interfaces.
+func (f *Fleet) GetInterface(ifName string) FleetReport {
+	return f.GetInterfaceContext(context.Background(), ifName)
+}
+
+// GetInterfaceContext is like GetInterface but honors ctx for cancellation and deadline.
+func (f *Fleet) GetInterfaceContext(ctx context.Context, ifName string) FleetReport {
+	return f.run(ctx, func(ctx context.Context, c *Client, res *FleetResult) {
+		res.Interfaces, res.Err = c.GetInterfaceContext(ctx, ifName)
+	})
+}
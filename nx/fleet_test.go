@@ -0,0 +1,39 @@
+package nx_test
+
+import (
+	"testing"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+func TestFleetZeroValue(t *testing.T) {
+	var f nx.Fleet
+
+	var servers []*nxtest.Server
+	for _, name := range []string{"leaf1", "leaf2"} {
+		srv := nxtest.NewServer()
+		defer srv.Close()
+		servers = append(servers, srv)
+
+		c, errNew := nx.New(srv.ClientOptions())
+		if errNew != nil {
+			t.Fatal(errNew)
+		}
+		f.Add(name, c)
+	}
+
+	if errLogin := f.Login().Err(); errLogin != nil {
+		t.Fatalf("Login: %v", errLogin)
+	}
+	defer f.Close()
+
+	if errAdd := f.AddVlan("10", "").Err(); errAdd != nil {
+		t.Fatalf("AddVlan: %v", errAdd)
+	}
+	for i, srv := range servers {
+		if _, _, found := srv.MO("sys/bd/bd-[vlan-10]"); !found {
+			t.Errorf("switch %d: vlan 10 not created", i)
+		}
+	}
+}
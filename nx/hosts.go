@@ -0,0 +1,171 @@
+package nx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HostPolicy selects which of ClientOptions.Hosts serves each request.
+type HostPolicy int
+
+// Host selection policies.
+const (
+	// HostSticky sends every request to the current host and fails over
+	// to the next healthy host only when the current one is unreachable.
+	HostSticky HostPolicy = iota
+	// HostRoundRobin spreads requests across all healthy hosts in turn.
+	// Each host holds its own session, opened on demand by re-login and
+	// refreshed by the keepalive enabled by ClientOptions.KeepAlive.
+	HostRoundRobin
+)
+
+// defaultHostRetryInterval is how long a failed host is skipped before it is probed again.
+const defaultHostRetryInterval = 30 * time.Second
+
+// HostStatus reports the health of one of ClientOptions.Hosts.
+type HostStatus struct {
+	Host      string    // Host name or address
+	Up        bool      // False if the last request to the host failed to reach it
+	LastError error     // Last transport error. Nil if the host is up.
+	RetryAt   time.Time // When a down host becomes eligible again. Zero if the host is up.
+}
+
+// hostPool tracks host health and orders hosts for each request.
+type hostPool struct {
+	mu       sync.Mutex
+	policy   HostPolicy
+	interval time.Duration
+	current  int // sticky: host in use; round-robin: next host to use
+	status   []HostStatus
+}
+
+func newHostPool(hosts []string, policy HostPolicy, interval time.Duration) *hostPool {
+	if interval <= 0 {
+		interval = defaultHostRetryInterval
+	}
+	p := &hostPool{policy: policy, interval: interval, status: make([]HostStatus, len(hosts))}
+	for i, h := range hosts {
+		p.status[i] = HostStatus{Host: h, Up: true}
+	}
+	return p
+}
+
+// candidates returns host indexes in the order they should be tried.
+// Healthy hosts come first, followed by down hosts due for re-probing.
+// Down hosts not yet due are tried last, only if nothing else is left.
+func (p *hostPool) candidates() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.status)
+	start := p.current
+	if p.policy == HostRoundRobin {
+		p.current = (p.current + 1) % n
+	}
+
+	now := time.Now()
+	var up, due, waiting []int
+	for k := 0; k < n; k++ {
+		i := (start + k) % n
+		st := p.status[i]
+		switch {
+		case st.Up:
+			up = append(up, i)
+		case !now.Before(st.RetryAt):
+			due = append(due, i)
+		default:
+			waiting = append(waiting, i)
+		}
+	}
+
+	return append(append(up, due...), waiting...)
+}
+
+// markUp records a successful exchange with host i.
+func (p *hostPool) markUp(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.status[i].Up = true
+	p.status[i].LastError = nil
+	p.status[i].RetryAt = time.Time{}
+
+	if p.policy == HostSticky {
+		p.current = i
+	}
+}
+
+// markDown records a failure to reach host i.
+func (p *hostPool) markDown(i int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.status[i].Up = false
+	p.status[i].LastError = err
+	p.status[i].RetryAt = time.Now().Add(p.interval)
+}
+
+// first returns the host preferred by the policy, without advancing round-robin.
+func (p *hostPool) first() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+func (p *hostPool) snapshot() []HostStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]HostStatus(nil), p.status...)
+}
+
+// HostStatus reports the health of each of ClientOptions.Hosts.
+func (c *Client) HostStatus() []HostStatus {
+	return c.hosts.snapshot()
+}
+
+type hostKey struct{}
+
+// withHost pins the requests made with ctx to host index i.
+func withHost(ctx context.Context, i int) context.Context {
+	return context.WithValue(ctx, hostKey{}, i)
+}
+
+// pinnedHost returns the host index pinned by withHost, if any.
+func pinnedHost(ctx context.Context) (int, bool) {
+	i, found := ctx.Value(hostKey{}).(int)
+	return i, found
+}
+
+// sessionGens returns the number of logins of each host.
+func (c *Client) sessionGens() []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]uint64(nil), c.sessions...)
+}
+
+// sessionHosts returns the indexes of hosts a session was opened on.
+func (c *Client) sessionHosts() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var hosts []int
+	for i, gen := range c.sessions {
+		if gen > 0 {
+			hosts = append(hosts, i)
+		}
+	}
+	return hosts
+}
+
+// relogin opens a new session on host i, unless one was opened since
+// sessionGens reported gen logins of i: requests refused with the same
+// expired token then log in once.
+func (c *Client) relogin(ctx context.Context, i int, gen uint64) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	if c.sessionGens()[i] != gen {
+		return nil // logged in meanwhile
+	}
+	return c.login(withHost(ctx, i))
+}
@@ -0,0 +1,103 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// InterfaceConfig holds the interface settings applied by UpdateInterface.
+// Zero values leave the corresponding setting unchanged.
+type InterfaceConfig struct {
+	AdminSt string  // AdminUp (no shutdown) or AdminDown (shutdown)
+	Descr   *string // Description. Pointer to empty string clears the description.
+	MTU     int     // MTU, 576-9216
+	Speed   string  // Speed: auto, 10M, 100M, 1G, 10G, 25G, 40G, 50G, 100G, 200G or 400G
+	Duplex  string  // Duplex: auto, full or half
+	AutoNeg string  // Auto-negotiation: on or off
+}
+
+// UpdateInterface applies cfg to ethernet or port-channel interface ifName
+// (Ex: ethernet:1/3 or port-channel:5).
+func (c *Client) UpdateInterface(ifName string, cfg InterfaceConfig) error {
+	return c.UpdateInterfaceContext(context.Background(), ifName, cfg)
+}
+
+// UpdateInterfaceContext is like UpdateInterface but honors ctx for cancellation and deadline.
+func (c *Client) UpdateInterfaceContext(ctx context.Context, ifName string, cfg InterfaceConfig) error {
+
+	tag, id, err := c.interfaceID(ifName)
+	if err != nil {
+		return err
+	}
+
+	jsonIf, err := formatInterfaceBody(tag, id, cfg)
+	if err != nil {
+		return err
+	}
+
+	c.debugf("interface update: Body=%s", jsonIf)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonIf))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// formatInterfaceBody formats the json body of interface update operations.
+func formatInterfaceBody(tag string, id string, cfg InterfaceConfig) (string, error) {
+	intf := NewMO(tag).Set("id", id)
+
+	switch cfg.AdminSt {
+	case "":
+	case AdminUp, AdminDown:
+		intf.Set("adminSt", cfg.AdminSt)
+	default:
+		return "", fmt.Errorf("Unexpected admin state: %s", cfg.AdminSt)
+	}
+
+	if cfg.Descr != nil {
+		intf.Set("descr", *cfg.Descr)
+	}
+
+	if cfg.MTU != 0 {
+		if cfg.MTU < 576 || cfg.MTU > 9216 {
+			return "", fmt.Errorf("bad mtu %d: expected 576-9216", cfg.MTU)
+		}
+		intf.Set("mtu", strconv.Itoa(cfg.MTU))
+	}
+
+	switch cfg.Speed {
+	case "":
+	case "auto", "10M", "100M", "1G", "10G", "25G", "40G", "50G", "100G", "200G", "400G":
+		intf.Set("speed", cfg.Speed)
+	default:
+		return "", fmt.Errorf("Unexpected speed: %s", cfg.Speed)
+	}
+
+	switch cfg.Duplex {
+	case "":
+	case "auto", "full", "half":
+		intf.Set("duplex", cfg.Duplex)
+	default:
+		return "", fmt.Errorf("Unexpected duplex: %s", cfg.Duplex)
+	}
+
+	switch cfg.AutoNeg {
+	case "":
+	case "on", "off":
+		intf.Set("autoNeg", cfg.AutoNeg)
+	default:
+		return "", fmt.Errorf("Unexpected autoneg: %s", cfg.AutoNeg)
+	}
+
+	if len(intf.Attributes) < 2 {
+		return "", fmt.Errorf("empty interface config for %s", id)
+	}
+
+	return configBody(NewMO("interfaceEntity").AddChild(intf)), nil
+}
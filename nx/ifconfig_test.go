@@ -0,0 +1,46 @@
+package nx
+
+import "testing"
+
+func TestFormatInterfaceBody(t *testing.T) {
+	descr := `to "core"`
+	noDescr := ""
+
+	tests := []struct {
+		cfg     InterfaceConfig
+		want    string
+		wantErr bool
+	}{
+		{cfg: InterfaceConfig{AdminSt: AdminDown, MTU: 9216},
+			want: `{"topSystem":{"children":[{"interfaceEntity":{"children":[{"l1PhysIf":{"attributes":{"adminSt":"down","id":"eth1/3","mtu":"9216"}}}]}}]}}`},
+		{cfg: InterfaceConfig{Descr: &descr, Speed: "10G", Duplex: "full", AutoNeg: "off"},
+			want: `{"topSystem":{"children":[{"interfaceEntity":{"children":[{"l1PhysIf":{"attributes":{"autoNeg":"off","descr":"to \"core\"","duplex":"full","id":"eth1/3","speed":"10G"}}}]}}]}}`},
+		{cfg: InterfaceConfig{Descr: &noDescr},
+			want: `{"topSystem":{"children":[{"interfaceEntity":{"children":[{"l1PhysIf":{"attributes":{"descr":"","id":"eth1/3"}}}]}}]}}`},
+		{cfg: InterfaceConfig{}, wantErr: true},
+		{cfg: InterfaceConfig{AdminSt: "off"}, wantErr: true},
+		{cfg: InterfaceConfig{MTU: 100}, wantErr: true},
+		{cfg: InterfaceConfig{MTU: 9217}, wantErr: true},
+		{cfg: InterfaceConfig{Speed: "10g"}, wantErr: true},
+		{cfg: InterfaceConfig{Speed: "10000"}, wantErr: true},
+		{cfg: InterfaceConfig{Duplex: "both"}, wantErr: true},
+		{cfg: InterfaceConfig{AutoNeg: "yes"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := formatInterfaceBody(EnetTag, "eth1/3", tt.cfg)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%+v: got %s, want error", tt.cfg, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %v", tt.cfg, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%+v:\n got %s\nwant %s", tt.cfg, got, tt.want)
+		}
+	}
+}
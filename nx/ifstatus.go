@@ -0,0 +1,216 @@
+package nx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InterfaceStatus holds the operational state of an ethernet (ethpmPhysIf)
+// or port-channel (ethpmAggrIf) interface.
+type InterfaceStatus struct {
+	Interface    string    // Interface in SplitInterfaceName form. Ex: ethernet:1/3
+	OperSt       string    // Operational state: up or down
+	OperStQual   string    // Reason for the operational state. Ex: link-failure
+	OperSpeed    string    // Operational speed. Ex: 10G
+	OperDuplex   string    // Operational duplex. Ex: full
+	OperMTU      int       // Operational MTU
+	LastLinkFlap time.Time // Last link state change. Zero if never changed or unknown.
+}
+
+// InterfaceCounters holds the rmon counters of an interface (rmonIfIn, rmonIfOut).
+type InterfaceCounters struct {
+	Interface string    // Interface in SplitInterfaceName form. Ex: ethernet:1/3
+	Time      time.Time // When the reply holding the counters was received
+
+	InOctets     uint64
+	InUcastPkts  uint64
+	InMcastPkts  uint64
+	InBcastPkts  uint64
+	InErrors     uint64
+	InDiscards   uint64
+	OutOctets    uint64
+	OutUcastPkts uint64
+	OutMcastPkts uint64
+	OutBcastPkts uint64
+	OutErrors    uint64
+	OutDiscards  uint64
+}
+
+// GetInterfaceStatus returns the operational state of interface ifName
+// (Ex: ethernet:1/3 or port-channel:5), or of all interfaces of a type
+// if ifName is ethernet or port-channel.
+func (c *Client) GetInterfaceStatus(ifName string) ([]InterfaceStatus, error) {
+	return c.GetInterfaceStatusContext(context.Background(), ifName)
+}
+
+// GetInterfaceStatusContext is like GetInterfaceStatus but honors ctx for cancellation and deadline.
+func (c *Client) GetInterfaceStatusContext(ctx context.Context, ifName string) ([]InterfaceStatus, error) {
+
+	var class, urifmt string
+
+	ifType, id, err := c.SplitInterfaceName(ifName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ifType {
+	case "ethernet":
+		class = "ethpmPhysIf"
+		urifmt = InterfaceEnetStatusURI
+	case "port-channel":
+		class = "ethpmAggrIf"
+		urifmt = InterfacePcStatusURI
+	default:
+		return nil, fmt.Errorf("Unexpected Interface name %s for status. Example Values: ethernet:1/3 or port-channel:5 or ethernet or port-channel", ifName)
+	}
+
+	uri := fmt.Sprintf(InterfaceAll, class)
+	if id != "" {
+		uri = fmt.Sprintf(urifmt, id)
+	}
+
+	body, errGet := c.get(ctx, uri)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	list, errAttr := jsonImdataAttributes(c, body, class, "GetInterfaceStatus")
+	if errAttr != nil {
+		return nil, errAttr
+	}
+
+	result := make([]InterfaceStatus, 0, len(list))
+	for _, m := range list {
+		result = append(result, newInterfaceStatus(m))
+	}
+
+	return result, nil
+}
+
+func newInterfaceStatus(m map[string]interface{}) InterfaceStatus {
+	flap, _ := time.Parse(time.RFC3339Nano, attrString(m, "lastLinkStChg"))
+	if flap.Year() <= 1970 {
+		flap = time.Time{} // never changed
+	}
+	return InterfaceStatus{
+		Interface:    interfaceFromDn(attrString(m, "dn")),
+		OperSt:       attrString(m, "operSt"),
+		OperStQual:   attrString(m, "operStQual"),
+		OperSpeed:    attrString(m, "operSpeed"),
+		OperDuplex:   attrString(m, "operDuplex"),
+		OperMTU:      attrInt(m, "operMtu"),
+		LastLinkFlap: flap,
+	}
+}
+
+// GetInterfaceCounters returns the rmon counters of interface ifName
+// (Ex: ethernet:1/3 or port-channel:5), or of all interfaces of a type
+// if ifName is ethernet or port-channel.
+func (c *Client) GetInterfaceCounters(ifName string) ([]InterfaceCounters, error) {
+	return c.GetInterfaceCountersContext(context.Background(), ifName)
+}
+
+// GetInterfaceCountersContext is like GetInterfaceCounters but honors ctx for cancellation and deadline.
+func (c *Client) GetInterfaceCountersContext(ctx context.Context, ifName string) ([]InterfaceCounters, error) {
+
+	var urifmt string
+
+	ifType, id, err := c.SplitInterfaceName(ifName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch ifType {
+	case "ethernet":
+		urifmt = InterfaceEnetCountersURI
+	case "port-channel":
+		urifmt = InterfacePcCountersURI
+	default:
+		return nil, fmt.Errorf("Unexpected Interface name %s for counters. Example Values: ethernet:1/3 or port-channel:5 or ethernet or port-channel", ifName)
+	}
+
+	uri := fmt.Sprintf(InterfaceAll, "rmonIfIn,rmonIfOut")
+	if id != "" {
+		uri = fmt.Sprintf(urifmt, id)
+	}
+
+	body, errGet := c.get(ctx, uri)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	// rmon objects carry no timestamp. Time the reply, not the request, so
+	// that request latency, retries and re-logins do not skew rates.
+	now := time.Now()
+
+	list, errObj := jsonImdataObjects(body)
+	if errObj != nil {
+		return nil, errObj
+	}
+
+	var order []string
+	byIf := map[string]*InterfaceCounters{}
+
+	for _, obj := range list {
+		name := interfaceFromDn(attrString(obj.attr, "dn"))
+		if name == "" || (id == "" && ifTypeOf(name) != ifType) {
+			continue
+		}
+		cnt, found := byIf[name]
+		if !found {
+			cnt = &InterfaceCounters{Interface: name, Time: now}
+			byIf[name] = cnt
+			order = append(order, name)
+		}
+		switch obj.class {
+		case "rmonIfIn":
+			cnt.InOctets = attrUint(obj.attr, "octets")
+			cnt.InUcastPkts = attrUint(obj.attr, "ucastPkts")
+			cnt.InMcastPkts = attrUint(obj.attr, "multicastPkts")
+			cnt.InBcastPkts = attrUint(obj.attr, "broadcastPkts")
+			cnt.InErrors = attrUint(obj.attr, "errors")
+			cnt.InDiscards = attrUint(obj.attr, "discards")
+		case "rmonIfOut":
+			cnt.OutOctets = attrUint(obj.attr, "octets")
+			cnt.OutUcastPkts = attrUint(obj.attr, "ucastPkts")
+			cnt.OutMcastPkts = attrUint(obj.attr, "multicastPkts")
+			cnt.OutBcastPkts = attrUint(obj.attr, "broadcastPkts")
+			cnt.OutErrors = attrUint(obj.attr, "errors")
+			cnt.OutDiscards = attrUint(obj.attr, "discards")
+		}
+	}
+
+	result := make([]InterfaceCounters, 0, len(order))
+	for _, name := range order {
+		result = append(result, *byIf[name])
+	}
+
+	return result, nil
+}
+
+// attrUint parses a counter imdata attribute. Missing or non-numeric values yield 0.
+func attrUint(m map[string]interface{}, key string) uint64 {
+	n, errConv := strconv.ParseUint(attrString(m, key), 10, 64)
+	if errConv != nil {
+		return 0
+	}
+	return n
+}
+
+// interfaceFromDn converts the dn of an interface, or of any object below it,
+// into SplitInterfaceName form.
+// Ex: sys/intf/phys-[eth1/3]/phys results in ethernet:1/3
+func interfaceFromDn(dn string) string {
+	if name := ethernetFromDn(dn); name != "" {
+		return name
+	}
+	return portChannelFromDn(dn)
+}
+
+// ifTypeOf returns the interface type of an interface name. Ex: ethernet:1/3 results in ethernet
+func ifTypeOf(ifName string) string {
+	return strings.SplitN(ifName, ":", 2)[0]
+}
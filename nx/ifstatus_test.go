@@ -0,0 +1,97 @@
+package nx_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+// slowReplies delays the replies to rmon counter queries by the next
+// duration of delays, if any.
+type slowReplies struct {
+	next http.RoundTripper
+
+	mu     sync.Mutex
+	delays []time.Duration
+}
+
+func (s *slowReplies) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, errTrip := s.next.RoundTrip(req)
+	if errTrip != nil || !strings.Contains(req.URL.RawQuery, "rmonIfIn") {
+		return resp, errTrip
+	}
+
+	s.mu.Lock()
+	var delay time.Duration
+	if len(s.delays) > 0 {
+		delay, s.delays = s.delays[0], s.delays[1:]
+	}
+	s.mu.Unlock()
+
+	time.Sleep(delay)
+	return resp, nil
+}
+
+func TestCounterPollerLatency(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	const dn = "sys/intf/phys-[eth1/3]"
+	srv.AddMO(dn, "l1PhysIf", map[string]string{"id": "eth1/3"})
+	srv.AddMO(dn+"/dbgIfIn", "rmonIfIn", map[string]string{"octets": "1000"})
+
+	opt := srv.ClientOptions()
+	slow := &slowReplies{next: nx.NewTransport(opt)}
+	opt.Transport = slow
+	c, errNew := nx.New(opt)
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	defer c.Logout()
+
+	// The rate interval is the time between replies, whatever the latency
+	// of each request.
+	for _, delays := range [][]time.Duration{
+		{400 * time.Millisecond, 0},
+		{0, 400 * time.Millisecond},
+	} {
+		slow.delays = delays
+		p := nx.NewCounterPoller(c, time.Second, "ethernet:1/3")
+
+		if _, errPoll := p.Poll(context.Background()); errPoll != nil {
+			t.Fatalf("first Poll: %v", errPoll)
+		}
+		first := time.Now()
+
+		srv.AddMO(dn+"/dbgIfIn", "rmonIfIn", map[string]string{"octets": "2000"})
+		time.Sleep(100 * time.Millisecond)
+
+		rates, errPoll := p.Poll(context.Background())
+		if errPoll != nil {
+			t.Fatalf("second Poll: %v", errPoll)
+		}
+		between := time.Since(first)
+
+		if len(rates) != 1 {
+			t.Fatalf("delays %v: got %d rates, want 1", delays, len(rates))
+		}
+		r := rates[0]
+		if d := r.Interval - between; d > 50*time.Millisecond || d < -50*time.Millisecond {
+			t.Errorf("delays %v: interval %v, want %v between replies", delays, r.Interval, between)
+		}
+		if want := 8 * 1000 / r.Interval.Seconds(); r.InBitsPerSec != want {
+			t.Errorf("delays %v: got %v bps, want %v", delays, r.InBitsPerSec, want)
+		}
+
+		srv.AddMO(dn+"/dbgIfIn", "rmonIfIn", map[string]string{"octets": "1000"})
+	}
+}
@@ -3,6 +3,8 @@ package nx
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 func jsonImdataAttributes(c hasDebugf, body []byte, key, label string) ([]map[string]interface{}, error) {
@@ -60,3 +62,67 @@ func extractKeyAttributes(c hasDebugf, list []interface{}, key, label string) []
 type hasDebugf interface {
 	debugf(fmt string, v ...interface{})
 }
+
+// attrString returns the string value of an imdata attribute, or "" if absent.
+func attrString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// attrInt parses a numeric imdata attribute. Missing or non-numeric values yield 0.
+func attrInt(m map[string]interface{}, key string) int {
+	n, errConv := strconv.Atoi(attrString(m, key))
+	if errConv != nil {
+		return 0
+	}
+	return n
+}
+
+// attrVlan parses a vlan encap attribute such as "vlan-129" into 129.
+// Missing or unexpected values yield 0.
+func attrVlan(m map[string]interface{}, key string) int {
+	s := strings.TrimPrefix(attrString(m, key), "vlan-")
+	n, errConv := strconv.Atoi(s)
+	if errConv != nil {
+		return 0
+	}
+	return n
+}
+
+// attrVlanSet parses a vlan list attribute such as "1-10,20,30-40".
+// Malformed values yield an empty set.
+func attrVlanSet(m map[string]interface{}, key string) VLANSet {
+	set, errParse := ParseVLANSet(attrString(m, key))
+	if errParse != nil {
+		return nil
+	}
+	return set
+}
+
+// imdataObject is a single managed object found in an imdata reply.
+type imdataObject struct {
+	class string
+	attr  map[string]interface{}
+}
+
+// jsonImdataObjects returns the class and attributes of every imdata member,
+// for replies mixing several object classes.
+func jsonImdataObjects(body []byte) ([]imdataObject, error) {
+	var reply struct {
+		Imdata []map[string]struct {
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"imdata"`
+	}
+	if errJSON := json.Unmarshal(body, &reply); errJSON != nil {
+		return nil, errJSON
+	}
+
+	result := make([]imdataObject, 0, len(reply.Imdata))
+	for _, item := range reply.Imdata {
+		for class, obj := range item {
+			result = append(result, imdataObject{class: class, attr: obj.Attributes})
+		}
+	}
+
+	return result, nil
+}
@@ -2,6 +2,7 @@ package nx
 
 import (
         "bytes"
+        "context"
         "fmt"
         "strings"
 )
@@ -37,49 +38,78 @@ func (c *Client) SplitInterfaceName(ifName string) (string, string, error) {
 // for adding trunk/native vlans to interface
 func (c *Client) formatTrunkBody(iftype string, id string, 
     allowed string, native string) (string, error) {
-    var vlancfg string
-    var tag string
-    var pfx string
+    tag, pfx, err := interfaceTag(iftype)
+    if err != nil {
+        return "", err
+    }
+
+    intf := NewMO(tag).Set("id", pfx+id).Set("mode", TrunkMode)
 
     if allowed == "None" {
         allowed = ""
     }
-    if native == "" {
-        vlancfg = fmt.Sprintf(TrunkVlans, allowed)
-    } else {
+    intf.Set("trunkVlans", allowed)
+
+    if native != "" {
         if native == "None" {
             native = "1"
         }
-        
-        s := []string{fmt.Sprintf(NativeVlan, native),
-                      fmt.Sprintf(TrunkVlans, allowed)}
-        vlancfg = strings.Join(s, ", ")
+        intf.Set("nativeVlan", "vlan-"+native)
     }
 
+    return configBody(NewMO("interfaceEntity").AddChild(intf)), nil
+}
+
+// interfaceTag returns the object class and id prefix for an interface type.
+// Ex: ethernet results in l1PhysIf and eth
+func interfaceTag(iftype string) (string, string, error) {
     switch iftype {
     case "ethernet":
         fallthrough
     case "enet":
-        tag = EnetTag
-        pfx = EnetPfx
+        return EnetTag, EnetPfx, nil
     case "port-channel":
         fallthrough
     case "po":
-        tag = PcTag
-        pfx = PcPfx
-    default:
-        return "", fmt.Errorf("Unexpected interface type: %s", iftype)
+        return PcTag, PcPfx, nil
     }
+    return "", "", fmt.Errorf("Unexpected interface type: %s", iftype)
+}
 
-    result := fmt.Sprintf(IfEntity, tag, pfx, id,
-                          TrunkMode, vlancfg)
-    return TopBegin+result+TopEnd, nil
+// interfaceID resolves a single interface name into its object class and id.
+// Ex: ethernet:1/3 results in l1PhysIf and eth1/3
+func (c *Client) interfaceID(ifName string) (string, string, error) {
+    ifType, ifId, err := c.SplitInterfaceName(ifName)
+    if err != nil {
+        return "", "", err
+    }
+    if ifId == "" {
+        return "", "", fmt.Errorf("missing interface id in %s. Example Values: ethernet:1/3 or port-channel:5", ifName)
+    }
+    tag, pfx, err := interfaceTag(ifType)
+    if err != nil {
+        return "", "", err
+    }
+    return tag, pfx + ifId, nil
 }
 
 // AddTrunkVlan - Adds trunk/native Vlan to interface
+// allowed is passed as is to the switch: a leading "+" adds to and a leading
+// "-" removes from the allowed vlans, otherwise allowed replaces them.
+// allowed "None" allows no vlan. native "None" restores native vlan 1 and
+// empty native leaves it unchanged.
+// AddAllowedVlans, RemoveAllowedVlans, SetAllowedVlans, SetNativeVlan and
+// ClearNativeVlan offer the same operations with explicit semantics.
 func (c *Client) AddTrunkVlan(ifName string, 
                               allowed string, 
                               native string) error {
+        return c.AddTrunkVlanContext(context.Background(), ifName, allowed, native)
+}
+
+// AddTrunkVlanContext is like AddTrunkVlan but honors ctx for cancellation and deadline.
+func (c *Client) AddTrunkVlanContext(ctx context.Context, ifName string,
+                                     allowed string,
+                                     native string) error {
 
         ifType, ifId, err := c.SplitInterfaceName(ifName)
         if err != nil {
@@ -93,7 +123,7 @@ func (c *Client) AddTrunkVlan(ifName string,
 
         c.debugf("Ethernet trunk vlan add: Body=%s", jsonTrunk)
 
-        body, errPost := c.post(ConfigRootURI, contentTypeJSON, 
+        body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON, 
                                 bytes.NewBufferString(jsonTrunk))
         if errPost != nil {
                 return errPost
@@ -102,7 +132,14 @@ func (c *Client) AddTrunkVlan(ifName string,
         return parseJSONError(body)
 }
 
+// GetInterface returns the attributes of ethernet or port-channel interfaces.
+// ifName is ethernet or port-channel, optionally followed by :id to select one.
 func (c *Client) GetInterface(ifName string) ([]map[string]interface{}, error) {
+    return c.GetInterfaceContext(context.Background(), ifName)
+}
+
+// GetInterfaceContext is like GetInterface but honors ctx for cancellation and deadline.
+func (c *Client) GetInterfaceContext(ctx context.Context, ifName string) ([]map[string]interface{}, error) {
 
     var uri, urifmt, key string
 
@@ -132,7 +169,7 @@ func (c *Client) GetInterface(ifName string) ([]map[string]interface{}, error) {
         uri = fmt.Sprintf(urifmt, id)
     }
 
-    body, errGet := c.get(uri)
+    body, errGet := c.get(ctx, uri)
     if errGet != nil {
             return nil, errGet
     }
@@ -140,3 +177,130 @@ func (c *Client) GetInterface(ifName string) ([]map[string]interface{}, error) {
     return jsonImdataAttributes(c, body, key, c.getFuncName(1))
 }
 
+// L1PhysIf holds the configured attributes of an ethernet interface (l1PhysIf).
+type L1PhysIf struct {
+    ID         string // Interface id. Ex: eth1/3
+    Dn         string // Distinguished name. Ex: sys/intf/phys-[eth1/3]
+    Descr      string // Interface description
+    AdminSt    string // Admin state: up or down
+    Mode       string // Switchport mode: access, trunk, ...
+    Layer      string // Layer2 or Layer3
+    NativeVlan int    // Trunk native vlan. 0 if unset
+    AccessVlan int    // Access vlan. 0 if unset
+    TrunkVlans VLANSet // Allowed trunk vlans
+    MTU        int    // Configured MTU
+    Speed      string // Configured speed. Ex: auto, 10G
+    Duplex     string // Configured duplex. Ex: auto, full
+    AutoNeg    string // Auto-negotiation: on or off
+}
+
+// PcAggrIf holds the configured attributes of a port-channel interface (pcAggrIf).
+type PcAggrIf struct {
+    ID         string // Interface id. Ex: po5
+    Dn         string // Distinguished name. Ex: sys/intf/aggr-[po5]
+    Name       string // Port-channel name
+    Descr      string // Interface description
+    AdminSt    string // Admin state: up or down
+    Mode       string // Switchport mode: access, trunk, ...
+    Layer      string // Layer2 or Layer3
+    PcMode     string // Channel mode: active, passive or on
+    NativeVlan int    // Trunk native vlan. 0 if unset
+    AccessVlan int    // Access vlan. 0 if unset
+    TrunkVlans VLANSet // Allowed trunk vlans
+    MTU        int    // Configured MTU
+    Speed      string // Configured speed. Ex: auto, 10G
+    Duplex     string // Configured duplex. Ex: auto, full
+    AutoNeg    string // Auto-negotiation: on or off
+}
+
+func newL1PhysIf(m map[string]interface{}) L1PhysIf {
+    return L1PhysIf{
+        ID:         attrString(m, "id"),
+        Dn:         attrString(m, "dn"),
+        Descr:      attrString(m, "descr"),
+        AdminSt:    attrString(m, "adminSt"),
+        Mode:       attrString(m, "mode"),
+        Layer:      attrString(m, "layer"),
+        NativeVlan: attrVlan(m, "nativeVlan"),
+        AccessVlan: attrVlan(m, "accessVlan"),
+        TrunkVlans: attrVlanSet(m, "trunkVlans"),
+        MTU:        attrInt(m, "mtu"),
+        Speed:      attrString(m, "speed"),
+        Duplex:     attrString(m, "duplex"),
+        AutoNeg:    attrString(m, "autoNeg"),
+    }
+}
+
+func newPcAggrIf(m map[string]interface{}) PcAggrIf {
+    return PcAggrIf{
+        ID:         attrString(m, "id"),
+        Dn:         attrString(m, "dn"),
+        Name:       attrString(m, "name"),
+        Descr:      attrString(m, "descr"),
+        AdminSt:    attrString(m, "adminSt"),
+        Mode:       attrString(m, "mode"),
+        Layer:      attrString(m, "layer"),
+        PcMode:     attrString(m, "pcMode"),
+        NativeVlan: attrVlan(m, "nativeVlan"),
+        AccessVlan: attrVlan(m, "accessVlan"),
+        TrunkVlans: attrVlanSet(m, "trunkVlans"),
+        MTU:        attrInt(m, "mtu"),
+        Speed:      attrString(m, "speed"),
+        Duplex:     attrString(m, "duplex"),
+        AutoNeg:    attrString(m, "autoNeg"),
+    }
+}
+
+// GetL1PhysIf returns typed ethernet interfaces.
+// id is the interface id (Ex: 1/3) or empty for all ethernet interfaces.
+func (c *Client) GetL1PhysIf(id string) ([]L1PhysIf, error) {
+    return c.GetL1PhysIfContext(context.Background(), id)
+}
+
+// GetL1PhysIfContext is like GetL1PhysIf but honors ctx for cancellation and deadline.
+func (c *Client) GetL1PhysIfContext(ctx context.Context, id string) ([]L1PhysIf, error) {
+
+    resp, errGet := c.GetInterfaceContext(ctx, joinInterfaceName("ethernet", id))
+    if errGet != nil {
+        return nil, errGet
+    }
+
+    result := make([]L1PhysIf, 0, len(resp))
+    for _, m := range resp {
+        result = append(result, newL1PhysIf(m))
+    }
+
+    return result, nil
+}
+
+// GetPcAggrIf returns typed port-channel interfaces.
+// id is the port-channel id (Ex: 5) or empty for all port-channels.
+func (c *Client) GetPcAggrIf(id string) ([]PcAggrIf, error) {
+    return c.GetPcAggrIfContext(context.Background(), id)
+}
+
+// GetPcAggrIfContext is like GetPcAggrIf but honors ctx for cancellation and deadline.
+func (c *Client) GetPcAggrIfContext(ctx context.Context, id string) ([]PcAggrIf, error) {
+
+    resp, errGet := c.GetInterfaceContext(ctx, joinInterfaceName("port-channel", id))
+    if errGet != nil {
+        return nil, errGet
+    }
+
+    result := make([]PcAggrIf, 0, len(resp))
+    for _, m := range resp {
+        result = append(result, newPcAggrIf(m))
+    }
+
+    return result, nil
+}
+
+// joinInterfaceName is the reverse of SplitInterfaceName.
+// Ex: ethernet and 1/12 results in ethernet:1/12
+func joinInterfaceName(ifType string, id string) string {
+    if id == "" {
+        return ifType
+    }
+    return ifType + ":" + id
+}
+
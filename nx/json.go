@@ -70,9 +70,5 @@ func parseJSONError(body []byte) error {
                 return nil // ok
         }
 
-        attr := mapSimple(e, "attributes")
-        code := mapString(attr, "code")
-        text := mapString(attr, "text")
-
-        return fmt.Errorf("error: code=%s text=%s", code, text)
+        return newImdataError(e)
 }
@@ -0,0 +1,100 @@
+package nx
+
+import (
+	"context"
+	"time"
+)
+
+// minKeepAlivePeriod bounds how often the background refresher calls aaaRefresh.
+const minKeepAlivePeriod = time.Second
+
+// startKeepAlive launches the background session refresher, unless it is already running.
+func (c *Client) startKeepAlive() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.keepAliveStop != nil {
+		return // already running
+	}
+
+	c.keepAliveStop = make(chan struct{})
+	c.keepAliveDone = make(chan struct{})
+
+	go c.keepAlive(c.keepAliveStop, c.keepAliveDone)
+}
+
+// stopKeepAlive stops the background session refresher and waits for it to exit.
+func (c *Client) stopKeepAlive() {
+	c.mu.Lock()
+	stop, done := c.keepAliveStop, c.keepAliveDone
+	c.keepAliveStop, c.keepAliveDone = nil, nil
+	c.mu.Unlock()
+
+	if stop == nil {
+		return // not running
+	}
+
+	close(stop)
+	<-done
+}
+
+// keepAlive calls aaaRefresh at half the session timeout reported by the switch,
+// on every healthy host a session was opened on.
+// If the refresh of a host fails, it falls back to a new login on that host.
+func (c *Client) keepAlive(stop, done chan struct{}) {
+	defer close(done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stop
+		cancel() // abort in-flight refresh
+	}()
+
+	for {
+		period := c.RefreshTimeout() / 2
+		if period < minKeepAlivePeriod {
+			period = minKeepAlivePeriod
+		}
+
+		timer := time.NewTimer(period)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		status := c.hosts.snapshot()
+		for _, i := range c.sessionHosts() {
+			if !status[i].Up {
+				continue // logged in again once reachable
+			}
+			c.keepAliveHost(withHost(ctx, i), i)
+		}
+	}
+}
+
+// keepAliveHost refreshes the session of host i, or logs in again.
+func (c *Client) keepAliveHost(ctx context.Context, i int) {
+	gen := c.sessionGens()[i]
+
+	errRefresh := c.RefreshContext(ctx)
+	if errRefresh == nil || ctx.Err() != nil {
+		return
+	}
+
+	c.debugf("keepalive: refresh host=%s: %v", c.Opt.Hosts[i], errRefresh)
+
+	if errLogin := c.relogin(ctx, i, gen); errLogin != nil {
+		c.logf("keepalive: login host=%s: %v", c.Opt.Hosts[i], errLogin)
+	}
+}
+
+// Close stops the background session refresher enabled by ClientOptions.KeepAlive,
+// ends all event subscriptions and closes the session using Logout.
+func (c *Client) Close() error {
+	c.stopKeepAlive()
+	c.closeSubscriptions()
+	return c.logout(context.Background())
+}
@@ -0,0 +1,86 @@
+package nx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+// requests counts the requests received by srv for uri.
+func requests(srv *nxtest.Server, uri string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.URI == uri {
+			n++
+		}
+	}
+	return n
+}
+
+// waitFor polls cond until it holds or timeout elapses.
+func waitFor(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return true
+}
+
+func TestKeepAlive(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+	srv.RefreshTimeout = 2 * time.Second
+
+	opt := srv.ClientOptions()
+	opt.KeepAlive = true
+	c, errNew := nx.New(opt)
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	start := time.Now()
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	defer c.Close()
+
+	if got := c.RefreshTimeout(); got != 2*time.Second {
+		t.Fatalf("RefreshTimeout = %v, want 2s", got)
+	}
+
+	// Refreshes come every half RefreshTimeout.
+	if !waitFor(5*time.Second, func() bool { return requests(srv, "/api/aaaRefresh.json") >= 2 }) {
+		t.Fatalf("got %d refreshes, want 2", requests(srv, "/api/aaaRefresh.json"))
+	}
+	if elapsed := time.Since(start); elapsed < 1900*time.Millisecond {
+		t.Errorf("2 refreshes after %v, want 1s apart", elapsed)
+	}
+
+	// A failed refresh falls back to a new login.
+	logins := requests(srv, "/api/aaaLogin.json")
+	srv.ExpireSessions()
+	if !waitFor(3*time.Second, func() bool { return requests(srv, "/api/aaaLogin.json") > logins }) {
+		t.Fatalf("no login after the session expired")
+	}
+	logins = requests(srv, "/api/aaaLogin.json")
+	if _, errGet := c.GetVlan(""); errGet != nil {
+		t.Fatalf("GetVlan after keepalive login: %v", errGet)
+	}
+	if got := requests(srv, "/api/aaaLogin.json"); got != logins {
+		t.Errorf("GetVlan after keepalive login: %d more logins", got-logins)
+	}
+
+	// Close stops the refresher.
+	if errClose := c.Close(); errClose != nil {
+		t.Fatalf("Close: %v", errClose)
+	}
+	refreshes := requests(srv, "/api/aaaRefresh.json")
+	time.Sleep(1500 * time.Millisecond)
+	if got := requests(srv, "/api/aaaRefresh.json"); got != refreshes {
+		t.Errorf("%d refreshes after Close", got-refreshes)
+	}
+}
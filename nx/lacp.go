@@ -0,0 +1,219 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LacpMemberState is the bundle state of a port-channel member, from its
+// channelingSt. Values other than the constants below are kept as reported.
+type LacpMemberState string
+
+// Port-channel member states.
+const (
+	LacpBundled      LacpMemberState = "bundled"       // Member is forwarding in the port-channel
+	LacpSuspended    LacpMemberState = "suspended"     // Member suspended, ex: no LACP PDUs received
+	LacpIndividual   LacpMemberState = "individual"    // Member forwarding as an individual link
+	LacpHotStandby   LacpMemberState = "hot-standby"   // Member held in standby by max-bundle
+	LacpNotChanneled LacpMemberState = "not-channeled" // Member not in the port-channel, ex: link down
+	LacpUnknown      LacpMemberState = "unknown"       // State not reported by the switch
+)
+
+// LacpMember holds the operational state of a port-channel member (pcRsMbrIfs).
+type LacpMember struct {
+	PortChannel  string          // Port-channel. Ex: port-channel:5
+	Interface    string          // Member interface. Ex: ethernet:1/3
+	State        LacpMemberState // Normalized channelingSt
+	ChannelingSt string          // Member state as reported by the switch. Ex: channeled
+	Up           bool            // Member link is up, whatever its bundle state
+}
+
+// Healthy reports whether the member is forwarding traffic for the port-channel.
+func (m LacpMember) Healthy() bool {
+	return m.State == LacpBundled
+}
+
+// SetPortChannelMode changes the channel mode of port-channel pcName
+// (Ex: port-channel:5) to PcModeActive, PcModePassive or PcModeOn.
+func (c *Client) SetPortChannelMode(pcName string, mode string) error {
+	return c.SetPortChannelModeContext(context.Background(), pcName, mode)
+}
+
+// SetPortChannelModeContext is like SetPortChannelMode but honors ctx for cancellation and deadline.
+func (c *Client) SetPortChannelModeContext(ctx context.Context, pcName string, mode string) error {
+
+	switch mode {
+	case PcModeActive, PcModePassive, PcModeOn:
+	default:
+		return fmt.Errorf("Unexpected port-channel mode: %s", mode)
+	}
+
+	pcId, err := c.portChannelID(pcName)
+	if err != nil {
+		return err
+	}
+
+	jsonPc := configBody(NewMO("interfaceEntity").AddChild(
+		NewMO(PcTag).Set("id", PcPfx+pcId).Set("pcMode", mode)))
+
+	return c.postLacp(ctx, "port-channel mode set", jsonPc)
+}
+
+// SetLacpRate sets the LACP rate, LacpRateFast or LacpRateNormal,
+// of ethernet interface ifName (Ex: ethernet:1/3).
+func (c *Client) SetLacpRate(ifName string, rate string) error {
+	return c.SetLacpRateContext(context.Background(), ifName, rate)
+}
+
+// SetLacpRateContext is like SetLacpRate but honors ctx for cancellation and deadline.
+func (c *Client) SetLacpRateContext(ctx context.Context, ifName string, rate string) error {
+
+	switch rate {
+	case LacpRateFast, LacpRateNormal:
+	default:
+		return fmt.Errorf("Unexpected lacp rate: %s", rate)
+	}
+
+	ifType, enetId, err := c.SplitInterfaceName(ifName)
+	if err != nil {
+		return err
+	}
+	if ifType != "ethernet" || enetId == "" {
+		return fmt.Errorf("Unexpected lacp interface %s. Example Value: ethernet:1/3", ifName)
+	}
+
+	jsonRate := configBody(NewMO("lacpEntity").AddChild(
+		NewMO("lacpInst").AddChild(
+			NewMO("lacpIf").Set("id", EnetPfx+enetId).Set("txRate", rate))))
+
+	return c.postLacp(ctx, "lacp rate set", jsonRate)
+}
+
+// SetLacpSystemPriority sets the LACP system priority, 1-65535.
+func (c *Client) SetLacpSystemPriority(prio int) error {
+	return c.SetLacpSystemPriorityContext(context.Background(), prio)
+}
+
+// SetLacpSystemPriorityContext is like SetLacpSystemPriority but honors ctx for cancellation and deadline.
+func (c *Client) SetLacpSystemPriorityContext(ctx context.Context, prio int) error {
+
+	if prio < 1 || prio > 65535 {
+		return fmt.Errorf("bad lacp system priority %d: expected 1-65535", prio)
+	}
+
+	jsonPrio := configBody(NewMO("lacpEntity").AddChild(
+		NewMO("lacpInst").Set("sysPrio", strconv.Itoa(prio))))
+
+	return c.postLacp(ctx, "lacp system priority set", jsonPrio)
+}
+
+func (c *Client) postLacp(ctx context.Context, label string, jsonBody string) error {
+
+	c.debugf("%s: Body=%s", label, jsonBody)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonBody))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// GetLacpMembers returns the member state of port-channel pcName (Ex: port-channel:5),
+// or of all port-channels if pcName is port-channel.
+func (c *Client) GetLacpMembers(pcName string) ([]LacpMember, error) {
+	return c.GetLacpMembersContext(context.Background(), pcName)
+}
+
+// GetLacpMembersContext is like GetLacpMembers but honors ctx for cancellation and deadline.
+func (c *Client) GetLacpMembersContext(ctx context.Context, pcName string) ([]LacpMember, error) {
+
+	ifType, pcId, err := c.SplitInterfaceName(pcName)
+	if err != nil {
+		return nil, err
+	}
+	if ifType != "port-channel" {
+		return nil, fmt.Errorf("Unexpected port-channel name %s. Example Values: port-channel:5 or port-channel", pcName)
+	}
+
+	uri := AllPcMbrURI
+	if pcId != "" {
+		uri = fmt.Sprintf(PcMbrAllURI, pcId)
+	}
+
+	body, errGet := c.get(ctx, uri)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	list, errAttr := jsonImdataAttributes(c, body, "pcRsMbrIfs", "GetLacpMembers")
+	if errAttr != nil {
+		return nil, errAttr
+	}
+
+	result := make([]LacpMember, 0, len(list))
+	for _, m := range list {
+		result = append(result, newLacpMember(m))
+	}
+
+	return result, nil
+}
+
+func newLacpMember(m map[string]interface{}) LacpMember {
+	channeling := attrString(m, "channelingSt")
+	return LacpMember{
+		PortChannel:  portChannelFromDn(memberParentDn(attrString(m, "dn"))),
+		Interface:    ethernetFromDn(attrString(m, "tDn")),
+		State:        lacpMemberState(channeling),
+		ChannelingSt: channeling,
+		Up:           attrString(m, "isMbrUp") == "yes",
+	}
+}
+
+// lacpMemberState normalizes pcRsMbrIfs channelingSt. Link state, such as
+// up or down, is not a bundle state: unknown values are returned as-is.
+func lacpMemberState(channeling string) LacpMemberState {
+	switch channeling {
+	case "":
+		return LacpUnknown
+	case "channeled", "bundled":
+		return LacpBundled
+	case "suspended":
+		return LacpSuspended
+	case "individual":
+		return LacpIndividual
+	case "hot-standby", "hot-stdby":
+		return LacpHotStandby
+	case "not-channeled":
+		return LacpNotChanneled
+	}
+	return LacpMemberState(channeling)
+}
+
+// memberParentDn strips the member relation from a pcRsMbrIfs dn.
+// Ex: sys/intf/aggr-[po5]/rsmbrIfs-[sys/intf/phys-[eth1/3]] results in sys/intf/aggr-[po5]
+func memberParentDn(dn string) string {
+	i := strings.Index(dn, "/rsmbrIfs-")
+	if i < 0 {
+		return dn
+	}
+	return dn[:i]
+}
+
+// ethernetFromDn converts an ethernet dn into SplitInterfaceName form.
+// Ex: sys/intf/phys-[eth1/3] results in ethernet:1/3
+func ethernetFromDn(dn string) string {
+	i := strings.Index(dn, "phys-[eth")
+	if i < 0 {
+		return ""
+	}
+	id := dn[i+len("phys-[eth"):]
+	if j := strings.Index(id, "]"); j >= 0 {
+		id = id[:j]
+	}
+	return joinInterfaceName("ethernet", id)
+}
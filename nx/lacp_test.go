@@ -0,0 +1,35 @@
+package nx
+
+import "testing"
+
+func TestLacpMemberState(t *testing.T) {
+	tests := []struct {
+		channeling string
+		want       LacpMemberState
+	}{
+		{"channeled", LacpBundled},
+		{"bundled", LacpBundled},
+		{"suspended", LacpSuspended},
+		{"individual", LacpIndividual},
+		{"hot-standby", LacpHotStandby},
+		{"hot-stdby", LacpHotStandby},
+		{"not-channeled", LacpNotChanneled},
+		{"", LacpUnknown},
+		// Link states are not bundle states.
+		{"up", "up"},
+		{"down", "down"},
+		{"wait", "wait"},
+	}
+
+	for _, tt := range tests {
+		if got := lacpMemberState(tt.channeling); got != tt.want {
+			t.Errorf("lacpMemberState(%q) = %q, want %q", tt.channeling, got, tt.want)
+		}
+	}
+
+	// A member link up but not bundled is not healthy.
+	m := newLacpMember(map[string]interface{}{"channelingSt": "suspended", "isMbrUp": "yes"})
+	if !m.Up || m.Healthy() {
+		t.Errorf("suspended member with link up: got Up %v, Healthy %v", m.Up, m.Healthy())
+	}
+}
@@ -0,0 +1,159 @@
+package nx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// MO is a managed object of the NX-API DME tree: a class name, its
+// attributes and its child objects. It marshals to and from the DME JSON
+// form used by request bodies and imdata replies:
+//
+//	{"l2BD": {"attributes": {"fabEncap": "vlan-10"}, "children": [...]}}
+//
+// Attribute values are JSON-escaped when marshaled.
+// Ex: vlan 10 named "web" under bdEntity
+//
+//	bd := NewMO("bdEntity").AddChild(
+//		NewMO("l2BD").Set("fabEncap", "vlan-10").Set("name", "web"))
+type MO struct {
+	Class      string            // Object class. Ex: l2BD
+	Attributes map[string]string // Attributes by name. Ex: fabEncap=vlan-10
+	Children   []*MO             // Child objects
+}
+
+// NewMO creates an object of class with no attributes nor children.
+func NewMO(class string) *MO {
+	return &MO{Class: class, Attributes: map[string]string{}}
+}
+
+// TopSystem wraps objects into the topSystem root expected by ConfigRootURI.
+func TopSystem(children ...*MO) *MO {
+	return NewMO("topSystem").AddChild(children...)
+}
+
+// Set sets attribute name to value and returns m, for chaining.
+func (m *MO) Set(name, value string) *MO {
+	if m.Attributes == nil {
+		m.Attributes = map[string]string{}
+	}
+	m.Attributes[name] = value
+	return m
+}
+
+// AddChild appends child objects and returns m, for chaining.
+func (m *MO) AddChild(children ...*MO) *MO {
+	m.Children = append(m.Children, children...)
+	return m
+}
+
+// Attr returns the value of attribute name, or "" if absent.
+func (m *MO) Attr(name string) string {
+	return m.Attributes[name]
+}
+
+// attrInt parses numeric attribute name. Missing or non-numeric values yield 0.
+func (m *MO) attrInt(name string) int {
+	n, errConv := strconv.Atoi(m.Attributes[name])
+	if errConv != nil {
+		return 0
+	}
+	return n
+}
+
+// ChildrenOf returns the direct children of class.
+func (m *MO) ChildrenOf(class string) []*MO {
+	var list []*MO
+	for _, child := range m.Children {
+		if child.Class == class {
+			list = append(list, child)
+		}
+	}
+	return list
+}
+
+// String returns the DME JSON form of m.
+func (m *MO) String() string {
+	buf, errJSON := json.Marshal(m)
+	if errJSON != nil {
+		return fmt.Sprintf("<MO %s: %v>", m.Class, errJSON)
+	}
+	return string(buf)
+}
+
+type jsonMO struct {
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Children   []*MO             `json:"children,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *MO) MarshalJSON() ([]byte, error) {
+	if m.Class == "" {
+		return nil, fmt.Errorf("MO: missing class")
+	}
+	return json.Marshal(map[string]jsonMO{m.Class: {Attributes: m.Attributes, Children: m.Children}})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Non-string attribute values are kept in their JSON text form.
+func (m *MO) UnmarshalJSON(buf []byte) error {
+	var obj map[string]struct {
+		Attributes map[string]json.RawMessage `json:"attributes"`
+		Children   []*MO                      `json:"children"`
+	}
+	if errJSON := json.Unmarshal(buf, &obj); errJSON != nil {
+		return errJSON
+	}
+	if len(obj) != 1 {
+		return fmt.Errorf("MO: expected a single class, found %d", len(obj))
+	}
+
+	for class, o := range obj {
+		m.Class = class
+		m.Attributes = make(map[string]string, len(o.Attributes))
+		for k, raw := range o.Attributes {
+			var s string
+			if json.Unmarshal(raw, &s) != nil {
+				s = string(bytes.TrimSpace(raw))
+			}
+			m.Attributes[k] = s
+		}
+		m.Children = o.Children
+	}
+
+	return nil
+}
+
+// ParseImdata decodes the objects of an imdata reply.
+// A reply holding an imdata error returns it as *Error.
+func ParseImdata(body []byte) ([]*MO, error) {
+	list, _, err := parseImdataPage(body)
+	return list, err
+}
+
+// parseImdataPage decodes the objects of an imdata reply and its totalCount,
+// the number of objects matching the query across all pages.
+func parseImdataPage(body []byte) ([]*MO, int, error) {
+	if errReply := parseJSONError(body); errReply != nil {
+		return nil, 0, errReply
+	}
+
+	var reply struct {
+		TotalCount string `json:"totalCount"`
+		Imdata     []*MO  `json:"imdata"`
+	}
+	if errJSON := json.Unmarshal(body, &reply); errJSON != nil {
+		return nil, 0, errJSON
+	}
+
+	total, _ := strconv.Atoi(reply.TotalCount)
+
+	return reply.Imdata, total, nil
+}
+
+// configBody returns the ConfigRootURI request body creating or updating objects.
+func configBody(children ...*MO) string {
+	return TopSystem(children...).String()
+}
@@ -2,7 +2,9 @@ package nx
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,6 +18,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -27,16 +30,50 @@ type ClientOptions struct {
 	User  string   // Username. If unspecified, env var NEXUS_USER is used.
 	Pass  string   // Password. If unspecified, env var NEXUS_PASS is used.
 	Debug bool     // Debug enables verbose debugging messages to console.
+
+	// KeepAlive enables a background goroutine, started by Login, that refreshes
+	// the session before RefreshTimeout() expires. Close() stops it.
+	KeepAlive bool
+
+	// TLS settings. By default the switch certificate is verified against the
+	// system CA pool and TLS 1.2 or later is required.
+	RootCAs            *x509.CertPool    // CA pool to verify switch certificates. If nil, the system pool is used.
+	Certificates       []tls.Certificate // Client certificates presented to the switch.
+	ServerName         string            // Name to verify switch certificates against. If empty, the host name is used.
+	MinTLSVersion      uint16            // Minimum TLS version. Ex: tls.VersionTLS12. If zero, TLS 1.2 is used.
+	MaxTLSVersion      uint16            // Maximum TLS version. If zero, the highest version supported is used.
+	InsecureSkipVerify bool              // Skip certificate verification. If unset, env var NEXUS_INSECURE is used.
+
+	// Host selection. Every request is sent according to HostPolicy and fails over
+	// to the other Hosts when a host cannot be reached.
+	HostPolicy        HostPolicy    // HostSticky (default) or HostRoundRobin
+	HostRetryInterval time.Duration // How long an unreachable host is skipped before it is probed again. Defaults to 30s.
+
+	BulkChunkSize int // Maximum objects per request of bulk operations like AddVlans. Defaults to 200.
+
+	// Transport, if set, carries every HTTP request instead of the transport
+	// built by NewTransport, and the TLS settings above are ignored.
+	Transport http.RoundTripper
 }
 
 // Client is an instance for interacting with Nexus switch using API calls.
 type Client struct {
 	Opt                 ClientOptions   // Options for the Nexus client
-	host                int             // Index for current host
+	hosts               *hostPool       // Host selection and health
 	cli                 *http.Client    // Client context for HTTP
 	loginToken          string          // Save Nexus login token
 	loginRefreshTimeout time.Duration   // Save Nexus refresh period
 	socket              *websocket.Conn // websocket for receiving notifications
+	socketHost          int             // Index of the host holding the websocket
+	subs                map[string]*Subscription // Subscriptions by id, fed by the websocket
+	socketMu            sync.Mutex      // Protects socket, socketHost and subs
+
+	mu            sync.Mutex    // Protects login state and keepalive channels
+	sessions      []uint64      // Per host: number of logins, 0 if no session was opened
+	keepAliveStop chan struct{} // Closed to stop the keepalive goroutine
+	keepAliveDone chan struct{} // Closed when the keepalive goroutine exits
+
+	loginMu sync.Mutex // Serializes logins again after a session expired
 }
 
 // Environment variables used as default parameters.
@@ -47,10 +84,14 @@ const (
 	NexusUser  = "NEXUS_USER"  // Env var. Username. Example: "joe"
 	NexusPass  = "NEXUS_PASS"  // Env var. Password. Example: "joesecret"
 	NexusDebug = "NEXUS_DEBUG" // Env var. Debug.
+	NexusInsecure = "NEXUS_INSECURE" // Env var. Skip TLS certificate verification.
 )
 
 const (
 	contentTypeJSON = "application/json" // Nexus API ignores Content-Type, but we set it rightly anyway
+
+	// defaultRequestTimeout bounds requests whose context carries no deadline.
+	defaultRequestTimeout = 15 * time.Second
 )
 
 // New creates a new Client instance for interacting with Nexus switch using API calls.
@@ -90,12 +131,33 @@ func New(o ClientOptions) (*Client, error) {
             _, o.Debug = os.LookupEnv(NexusDebug)
         }
 
+	if !o.InsecureSkipVerify {
+		_, o.InsecureSkipVerify = os.LookupEnv(NexusInsecure)
+	}
+
+	if o.HostPolicy != HostSticky && o.HostPolicy != HostRoundRobin {
+		return nil, fmt.Errorf("bad host policy: %d", o.HostPolicy)
+	}
+
+	if o.MaxTLSVersion != 0 && minTLSVersion(o) > o.MaxTLSVersion {
+		return nil, fmt.Errorf("bad TLS versions: min=%#x max=%#x", minTLSVersion(o), o.MaxTLSVersion)
+	}
+
 	c := &Client{Opt: o}
 
-	c.newHTTPClient()
+	c.hosts = newHostPool(o.Hosts, o.HostPolicy, o.HostRetryInterval)
+	c.sessions = make([]uint64, len(o.Hosts))
+
+	if errHTTP := c.newHTTPClient(); errHTTP != nil {
+		return nil, errHTTP
+	}
 
 	c.debugf("new client: hosts=%s user=%s pass=%s", c.Opt.Hosts, c.Opt.User, c.Opt.Pass)
 
+	if c.Opt.InsecureSkipVerify {
+		c.logf("TLS certificate verification disabled")
+	}
+
 	return c, nil
 }
 
@@ -103,7 +165,6 @@ func (c *Client) getFuncName(level int) string {
     pc, _, _, _ := runtime.Caller(level)
     f := runtime.FuncForPC(pc)
     x := strings.SplitAfter(f.Name(), ".")
-    fmt.Println(x, x[len(x)-1])
     if len(x) > 0 {
         return x[len(x)-1]
     }   
@@ -122,11 +183,25 @@ func (c *Client) logf(fmt string, v ...interface{}) {
 }
 
 func (c *Client) jsonAaaUser() string {
-	return fmt.Sprintf(`{"aaaUser": {"attributes": {"name": "%s", "pwd": "%s"}}}`, c.Opt.User, c.Opt.Pass)
+	return NewMO("aaaUser").Set("name", c.Opt.User).Set("pwd", c.Opt.Pass).String()
 }
 
-// Logout closes a session to Nexus Switch using the API aaaLogout.
+// Logout closes the session of every host logged in using the API aaaLogout.
+// It also stops the background session refresher enabled by ClientOptions.KeepAlive.
 func (c *Client) Logout() {
+	c.LogoutContext(context.Background())
+}
+
+// LogoutContext is like Logout but honors ctx for cancellation and deadline.
+func (c *Client) LogoutContext(ctx context.Context) {
+	if errLogout := c.logout(ctx); errLogout != nil {
+		c.logf("Failed to logout User: Error: %s", errLogout)
+	}
+}
+
+func (c *Client) logout(ctx context.Context) error {
+
+	c.stopKeepAlive() // a refresh failing after logout would log in again
 
 	api := "/api/aaaLogout.json"
 
@@ -136,19 +211,43 @@ func (c *Client) Logout() {
 
 	//c.debugf("logout: url=%s json=%s", url, aaaUser)
 
-	body, errPost := c.post(api, contentTypeJSON, bytes.NewBufferString(aaaUser))
-	if errPost != nil {
-                c.logf("Failed to logout User: Error: %s", errPost)
-		return
+	var last error
+
+	for _, i := range c.sessionHosts() {
+		body, errPost := c.post(withHost(ctx, i), api, contentTypeJSON, bytes.NewBufferString(aaaUser))
+		if errPost != nil {
+			last = errPost
+			continue
+		}
+		c.debugf("logout: host=%s reply: %s", c.Opt.Hosts[i], string(body))
 	}
 
-	c.debugf("logout: reply: %s", string(body))
+	c.mu.Lock()
+	c.sessions = make([]uint64, len(c.Opt.Hosts))
+	c.mu.Unlock()
 
-	return
+	return last
 }
 
 // Login opens a new session into Nexus Switch using the API aaaLogin.
 func (c *Client) Login() error {
+	return c.LoginContext(context.Background())
+}
+
+// LoginContext is like Login but honors ctx for cancellation and deadline.
+func (c *Client) LoginContext(ctx context.Context) error {
+	if errLogin := c.login(ctx); errLogin != nil {
+		return errLogin
+	}
+
+	if c.Opt.KeepAlive {
+		c.startKeepAlive()
+	}
+
+	return nil
+}
+
+func (c *Client) login(ctx context.Context) error {
 
 	api := "/api/aaaLogin.json"
 
@@ -156,7 +255,7 @@ func (c *Client) Login() error {
 
 	c.debugf("login: api=%s json=%s", api, aaaUser)
 
-	body, errPost := c.postScan(api, contentTypeJSON, bytes.NewBufferString(aaaUser))
+	body, host, errPost := c.do(ctx, "POST", api, contentTypeJSON, []byte(aaaUser))
 	if errPost != nil {
 		return errPost
 	}
@@ -185,10 +284,7 @@ func (c *Client) Login() error {
 	for k, v := range mm {
 		switch k {
 		case "error":
-			attr := mapSimple(v, "attributes")
-			code := mapString(attr, "code")
-			text := mapString(attr, "text")
-			return fmt.Errorf("login: error: code=%s text=%s", code, text)
+			return newImdataError(v)
 		case "aaaLogin":
 			attr := mapSimple(v, "attributes")
 			token := mapString(attr, "token")
@@ -196,6 +292,10 @@ func (c *Client) Login() error {
 
 			c.refresh(token, refresh)
 
+			c.mu.Lock()
+			c.sessions[host]++
+			c.mu.Unlock()
+
 			return nil // ok
 		}
 	}
@@ -204,14 +304,18 @@ func (c *Client) Login() error {
 }
 
 // Refresh resets the session timer on Nexus Switch using the API aaaRefresh.
-// In order to keep the session active, Refresh() must be called at a period lower than the timeout reported by RefreshTimeout().
+// In order to keep the session active, Refresh() must be called at a period lower than the timeout reported by RefreshTimeout(),
+// unless ClientOptions.KeepAlive is set.
 func (c *Client) Refresh() error {
+	return c.RefreshContext(context.Background())
+}
 
-	api := "/api/aaaRefresh.json"
+// RefreshContext is like Refresh but honors ctx for cancellation and deadline.
+func (c *Client) RefreshContext(ctx context.Context) error {
 
-	url := c.getURL(api)
+	api := "/api/aaaRefresh.json"
 
-	body, errGet := c.get(url)
+	body, errGet := c.get(ctx, api)
 	if errGet != nil {
 		return errGet
 	}
@@ -240,10 +344,7 @@ func (c *Client) Refresh() error {
 	for k, v := range mm {
 		switch k {
 		case "error":
-			attr := mapSimple(v, "attributes")
-			code := mapString(attr, "code")
-			text := mapString(attr, "text")
-			return fmt.Errorf("refresh: error: code=%s text=%s", code, text)
+			return newImdataError(v)
 		case "aaaLogin":
 			attr := mapSimple(v, "attributes")
 			token := mapString(attr, "token")
@@ -259,6 +360,9 @@ func (c *Client) Refresh() error {
 }
 
 func (c *Client) refresh(token, refreshTimeout string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.loginToken = token // save token
 
 	timeout, timeoutErr := strconv.Atoi(refreshTimeout)
@@ -268,28 +372,46 @@ func (c *Client) refresh(token, refreshTimeout string) {
 	}
 	c.loginRefreshTimeout = time.Duration(timeout) * time.Second // save timeout
 
-	c.debugf("refresh: timeout=%v token=%s", c.RefreshTimeout(), token)
+	c.debugf("refresh: timeout=%v token=%s", c.loginRefreshTimeout, token)
 }
 
 // RefreshTimeout gets the session timeout reported by last API call to Nexus Switch.
 // In order to keep the session active, Refresh() must be called at a period lower than the timeout reported by RefreshTimeout().
 func (c *Client) RefreshTimeout() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.loginRefreshTimeout
 }
 
-func tlsConfig() *tls.Config {
+func (c *Client) tlsConfig() *tls.Config {
+	return newTLSConfig(c.Opt)
+}
+
+// minTLSVersion returns the minimum TLS version enforced for options o.
+func minTLSVersion(o ClientOptions) uint16 {
+	if o.MinTLSVersion == 0 {
+		return tls.VersionTLS12
+	}
+	return o.MinTLSVersion
+}
+
+func newTLSConfig(o ClientOptions) *tls.Config {
 	return &tls.Config{
-		CipherSuites:             []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA, tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA},
-		PreferServerCipherSuites: true,
-		InsecureSkipVerify:       true,
-		MaxVersion:               tls.VersionTLS11,
-		MinVersion:               tls.VersionTLS11,
+		RootCAs:            o.RootCAs,
+		Certificates:       o.Certificates,
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+		MinVersion:         minTLSVersion(o),
+		MaxVersion:         o.MaxTLSVersion,
 	}
 }
 
-func (c *Client) newHTTPClient() {
-	tr := &http.Transport{
-		TLSClientConfig:    tlsConfig(),
+// NewTransport builds the HTTPS transport used by a Client created with options o,
+// when ClientOptions.Transport is unset. Use it as the next hop of a custom
+// http.RoundTripper, such as a request recorder.
+func NewTransport(o ClientOptions) http.RoundTripper {
+	return &http.Transport{
+		TLSClientConfig:    newTLSConfig(o),
 		DisableCompression: true,
 		DisableKeepAlives:  true,
 		Dial: (&net.Dialer{
@@ -297,23 +419,43 @@ func (c *Client) newHTTPClient() {
 			KeepAlive: 10 * time.Second,
 		}).Dial,
 		TLSHandshakeTimeout:   10 * time.Second,
-		ResponseHeaderTimeout: 10 * time.Second,
 		ExpectContinueTimeout: 1 * time.Second,
 	}
+}
+
+func (c *Client) newHTTPClient() error {
+	tr := c.Opt.Transport
+	if tr == nil {
+		tr = NewTransport(c.Opt)
+	}
+	// The jar is created once here and never replaced, since requests
+	// from the keepalive goroutine and callers share it.
+	jar, errJar := cookiejar.New(nil)
+	if errJar != nil {
+		return errJar
+	}
+	// No http.Client.Timeout: requests are bounded by their context,
+	// or by defaultRequestTimeout when the context has no deadline.
 	c.cli = &http.Client{
 		Transport: tr,
-		Timeout:   15 * time.Second,
+		Jar:       jar,
 	}
+	return nil
 }
 
 // getURL builds HTTPS URL for API access.
 func (c *Client) getURL(api string) string {
-	return makeURL("https", c.Opt.Hosts[c.host], api)
+	return c.hostURL(c.hosts.first(), api)
+}
+
+// hostURL builds HTTPS URL for API access to host index i.
+func (c *Client) hostURL(i int, api string) string {
+	return makeURL("https", c.Opt.Hosts[i], api)
 }
 
 // getURLws builds websocket URL for notifications.
 func (c *Client) getURLws(api string) string {
-	return makeURL("wss", c.Opt.Hosts[c.host], api)
+	return makeURL("wss", c.Opt.Hosts[c.hosts.first()], api)
 }
 
 // url builds URL from protocol, host, path.
@@ -321,38 +463,7 @@ func makeURL(proto, host, path string) string {
 	return proto + "://" + host + path
 }
 
-// postScan scans multiple Nexus Switch hosts.
-func (c *Client) postScan(api string, contentType string, r io.Reader) ([]byte, error) {
-	var last error
-
-	if isURL(api) {
-		return nil, fmt.Errorf("bad api=%s", api)
-	}
-
-	for ; c.host < len(c.Opt.Hosts); c.host++ {
-
-		//url := c.getURL(api)
-
-                url := api
-		body, errPost := c.post(url, contentType, r)
-		if errPost != nil {
-			c.debugf("postScan: error: apic: %s: %v", url, errPost)
-			last = errPost
-			continue
-		}
-
-		return body, nil
-	}
-
-	return nil, fmt.Errorf("no more apic hosts to try - last: %v", last)
-}
-
 func (c *Client) showCookies(urlStr string) {
-	if c.cli.Jar == nil {
-		c.debugf("no cookies to send")
-		return
-	}
-
 	u, errURL := url.Parse(urlStr)
 	if errURL != nil {
 		c.debugf("showCookies: %s: %v", urlStr, errURL)
@@ -376,13 +487,6 @@ func (c *Client) learnCookies(resp *http.Response) error {
 		c.debugf("learnCookies: seen: url=%s cookie=%s", resp.Request.URL, ck.Name)
                 // CB_TBD What's Nexus substitute for APIC-cookie? s/b "Set-Cookie" ??
 		if ck.Name == "APIC-cookie" {
-			if c.cli.Jar == nil {
-				var errNew error
-				c.cli.Jar, errNew = cookiejar.New(nil) // new jar
-				if errNew != nil {
-					return errNew
-				}
-			}
 			c.cli.Jar.SetCookies(resp.Request.URL, []*http.Cookie{ck}) // add single cookie to jar
 			c.debugf("learnCookies: learnt: url=%s cookie=%s value=%s", resp.Request.URL, ck.Name, ck.Value)
 			break
@@ -391,96 +495,121 @@ func (c *Client) learnCookies(resp *http.Response) error {
 	return nil
 }
 
-func (c *Client) post(uri string, contentType string, r io.Reader) ([]byte, error) {
-
-        url := c.getURL(uri)
-	if !isURL(url) {
-		return nil, fmt.Errorf("bad URL=%s", url)
+func (c *Client) post(ctx context.Context, uri string, contentType string, r io.Reader) ([]byte, error) {
+	var payload []byte
+	if r != nil {
+		var errRead error
+		payload, errRead = ioutil.ReadAll(r)
+		if errRead != nil {
+			return nil, errRead
+		}
 	}
+	return c.retry(ctx, "POST", uri, contentType, payload)
+}
 
-        callerFuncName := c.getFuncName(2)
-	c.debugf("post: Caller %s apic endpoint: %s",
-                 callerFuncName, url)
-
-	c.showCookies(url)
+func (c *Client) get(ctx context.Context, uri string) ([]byte, error) {
+	return c.retry(ctx, "GET", uri, "", nil)
+}
 
-	resp, errPost := c.cli.Post(url, contentType, r)
-	if errPost != nil {
-		return nil, errPost
-	}
-	defer resp.Body.Close()
+func (c *Client) delete(ctx context.Context, uri string) ([]byte, error) {
+	return c.retry(ctx, "DELETE", uri, "", nil)
+}
 
-	if errLearn := c.learnCookies(resp); errLearn != nil {
-		return nil, errLearn
-	}
+// retry sends the request and, if the session token has expired, logs in
+// again and resends the request once.
+func (c *Client) retry(ctx context.Context, method, uri, contentType string, payload []byte) ([]byte, error) {
 
-	body, errBody := ioutil.ReadAll(resp.Body)
-        if (errBody !=nil) {
-            return nil, errBody
-        }
+	sessions := c.sessionGens()
 
-        c.debugf("%s: reply: %s", callerFuncName, string(body))
+	body, host, errDo := c.do(ctx, method, uri, contentType, payload)
+	if errDo == nil || isAaaAPI(uri) || !isTokenExpired(errDo) {
+		return body, errDo
+	}
 
-	return body, errBody
-}
+	c.debugf("%s %s: session expired, login again: %v", method, uri, errDo)
 
-func (c *Client) get(uri string) ([]byte, error) {
+	ctx = withHost(ctx, host) // the session lives on the host that refused the token
 
-        url := c.getURL(uri)
-	if !isURL(url) {
-		return nil, fmt.Errorf("bad URL=%s", url)
+	if errLogin := c.relogin(ctx, host, sessions[host]); errLogin != nil {
+		c.logf("%s %s: login again: %v", method, uri, errLogin)
+		return nil, errDo
 	}
 
-        callerFuncName := c.getFuncName(2)
-	c.debugf("get: Caller %s apic endpoint: %s", 
-                 callerFuncName, url)
+	body, _, errDo = c.do(ctx, method, uri, contentType, payload)
+	return body, errDo
+}
 
-	c.showCookies(url)
+// do performs the HTTP exchange with the first Nexus host that can be reached,
+// in the order given by the host selection policy, unless ctx pins a host.
+// It returns the index of the host that served the request.
+func (c *Client) do(ctx context.Context, method, uri, contentType string, payload []byte) ([]byte, int, error) {
 
-	resp, errPost := c.cli.Get(url)
-	if errPost != nil {
-		return nil, errPost
-	}
-	defer resp.Body.Close()
+	callerFuncName := c.getFuncName(4)
 
-	if errLearn := c.learnCookies(resp); errLearn != nil {
-		return nil, errLearn
+	hosts := c.hosts.candidates()
+	if i, pinned := pinnedHost(ctx); pinned {
+		hosts = []int{i}
 	}
 
-	body, errBody := ioutil.ReadAll(resp.Body)
-        if (errBody !=nil) {
-            return nil, errBody
-        }
+	var last error
 
-        //c.debugf("%s: reply: %s", callerFuncName, string(body))
+	for _, i := range hosts {
+		body, errEx := c.exchange(ctx, callerFuncName, i, method, uri, contentType, payload)
+		if errEx == nil {
+			c.hosts.markUp(i)
+			return body, i, nil
+		}
+		if _, isErr := asError(errEx); isErr {
+			c.hosts.markUp(i)
+			return nil, i, errEx // host reached but request refused
+		}
+		if ctx.Err() != nil {
+			return nil, i, errEx // canceled: do not blame the host
+		}
 
-        return body, nil
-}
+		c.debugf("%s: error: host %s: %v", callerFuncName, c.Opt.Hosts[i], errEx)
+		c.hosts.markDown(i, errEx)
+		last = errEx
+	}
 
-func isURL(url string) bool {
-	return strings.HasPrefix(url, "https://")
+	return nil, -1, fmt.Errorf("no more Nexus hosts to try - last: %w", last)
 }
 
-func (c *Client) delete(uri string) ([]byte, error) {
+// exchange performs a single HTTP exchange with Nexus host index i.
+func (c *Client) exchange(ctx context.Context, callerFuncName string, i int, method, uri, contentType string, payload []byte) ([]byte, error) {
 
-        url := c.getURL(uri)
+	url := c.hostURL(i, uri)
 	if !isURL(url) {
 		return nil, fmt.Errorf("bad URL=%s", url)
 	}
-        callerFuncName := c.getFuncName(2)
-	c.debugf("delete: Caller %s apic endpoint: %s",
-                 callerFuncName, url)
+
+	c.debugf("%s: Caller %s apic endpoint: %s",
+		strings.ToLower(method), callerFuncName, url)
 
 	c.showCookies(url)
 
-	req, errNew := http.NewRequest("DELETE", url, nil)
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultRequestTimeout)
+		defer cancel()
+	}
+
+	var r io.Reader
+	if payload != nil {
+		r = bytes.NewReader(payload)
+	}
+
+	req, errNew := http.NewRequestWithContext(ctx, method, url, r)
 	if errNew != nil {
 		return nil, errNew
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
-	resp, errDel := c.cli.Do(req)
-	if errDel != nil {
-		return nil, errDel
+	resp, errDo := c.cli.Do(req)
+	if errDo != nil {
+		return nil, errDo
 	}
 	defer resp.Body.Close()
 
@@ -489,11 +618,26 @@ func (c *Client) delete(uri string) ([]byte, error) {
 	}
 
 	body, errBody := ioutil.ReadAll(resp.Body)
-        if (errBody !=nil) {
-            return nil, errBody
-        }
+	if errBody != nil {
+		return nil, errBody
+	}
+
+	if method != "GET" {
+		c.debugf("%s: reply: %s", callerFuncName, string(body))
+	}
+
+	if errReply := replyError(method, url, resp.StatusCode, body); errReply != nil {
+		return nil, errReply
+	}
+
+	return body, nil
+}
 
-        c.debugf("%s: reply: %s", callerFuncName, string(body))
+func isURL(url string) bool {
+	return strings.HasPrefix(url, "https://")
+}
 
-	return body, errBody
+// isAaaAPI reports whether uri is one of the aaaLogin, aaaRefresh or aaaLogout session APIs.
+func isAaaAPI(uri string) bool {
+	return strings.HasPrefix(uri, "/api/aaa")
 }
@@ -0,0 +1,34 @@
+package nx
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestNewTLSVersions(t *testing.T) {
+	tests := []struct {
+		min, max uint16
+		wantErr  bool
+	}{
+		{},
+		{max: tls.VersionTLS13},
+		{max: tls.VersionTLS12},
+		{max: tls.VersionTLS11, wantErr: true},
+		{min: tls.VersionTLS10, max: tls.VersionTLS11},
+		{min: tls.VersionTLS13, max: tls.VersionTLS12, wantErr: true},
+		{min: tls.VersionTLS13},
+	}
+
+	for _, tt := range tests {
+		_, err := New(ClientOptions{
+			Hosts:         []string{"192.0.2.1"},
+			User:          "admin",
+			Pass:          "password",
+			MinTLSVersion: tt.min,
+			MaxTLSVersion: tt.max,
+		})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("New(min=%#x, max=%#x): got %v, want error %v", tt.min, tt.max, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package nx
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// CounterRate holds the rates computed from two successive counter reads of an interface.
+type CounterRate struct {
+	Interface string        // Interface in SplitInterfaceName form. Ex: ethernet:1/3
+	Time      time.Time     // Time of the last counter read
+	Interval  time.Duration // Time elapsed since the previous counter read
+	Reset     bool          // Some counters went backwards, cleared on the switch. Their rates count from zero.
+
+	InBitsPerSec      float64
+	OutBitsPerSec     float64
+	InPktsPerSec      float64 // Unicast, multicast and broadcast
+	OutPktsPerSec     float64 // Unicast, multicast and broadcast
+	InErrorsPerSec    float64
+	OutErrorsPerSec   float64
+	InDiscardsPerSec  float64
+	OutDiscardsPerSec float64
+}
+
+// CounterPoller periodically reads the rmon counters of interfaces and
+// computes bit, packet, error and discard rates.
+type CounterPoller struct {
+	Client      *Client
+	Interfaces  []string      // Interface names. Ex: ethernet:1/3, port-channel:5, or ethernet for all
+	Interval    time.Duration // Polling period. Defaults to 30s.
+	CounterBits int           // Counter width, 32 or 64 (default). Selects wrap handling.
+	OnError     func(error)   // Called when a poll fails. If nil, errors are logged.
+
+	prev map[string]InterfaceCounters
+}
+
+const defaultPollInterval = 30 * time.Second
+
+// NewCounterPoller creates a poller for interfaces ifNames reached through c.
+func NewCounterPoller(c *Client, interval time.Duration, ifNames ...string) *CounterPoller {
+	return &CounterPoller{Client: c, Interfaces: ifNames, Interval: interval}
+}
+
+// Run polls until ctx is done, calling fn with the rates computed at each poll.
+// The first poll only primes the counters, so fn is first called after one Interval.
+func (p *CounterPoller) Run(ctx context.Context, fn func([]CounterRate)) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		rates, errPoll := p.Poll(ctx)
+		switch {
+		case errPoll != nil && ctx.Err() == nil:
+			p.failed(errPoll)
+		case len(rates) > 0:
+			fn(rates)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start runs the poller in background and delivers rates on the returned
+// channel, which is closed when ctx is done.
+func (p *CounterPoller) Start(ctx context.Context) <-chan []CounterRate {
+	ch := make(chan []CounterRate, 1)
+	go func() {
+		defer close(ch)
+		p.Run(ctx, func(rates []CounterRate) {
+			select {
+			case ch <- rates:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
+
+// Poll reads the counters once and returns the rates since the previous Poll.
+// Interfaces read for the first time yield no rate.
+// A failed Poll returns no rate and keeps the previous counters, so the
+// next Poll computes rates over the whole period since the last success.
+func (p *CounterPoller) Poll(ctx context.Context) ([]CounterRate, error) {
+	next := map[string]InterfaceCounters{}
+
+	for _, ifName := range p.Interfaces {
+		list, errGet := p.Client.GetInterfaceCountersContext(ctx, ifName)
+		if errGet != nil {
+			return nil, errGet
+		}
+		for _, cur := range list {
+			next[cur.Interface] = cur
+		}
+	}
+
+	return p.update(next), nil
+}
+
+// update computes the rates from the previous counters to next, then
+// records next as the previous counters.
+func (p *CounterPoller) update(next map[string]InterfaceCounters) []CounterRate {
+	if p.prev == nil {
+		p.prev = map[string]InterfaceCounters{}
+	}
+
+	var rates []CounterRate
+
+	for _, ifName := range sortedKeys(next) {
+		cur := next[ifName]
+		prev, found := p.prev[ifName]
+		p.prev[ifName] = cur
+		if !found || !cur.Time.After(prev.Time) {
+			continue
+		}
+		rates = append(rates, p.rate(prev, cur))
+	}
+
+	return rates
+}
+
+func sortedKeys(m map[string]InterfaceCounters) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (p *CounterPoller) rate(prev, cur InterfaceCounters) CounterRate {
+	r := CounterRate{
+		Interface: cur.Interface,
+		Time:      cur.Time,
+		Interval:  cur.Time.Sub(prev.Time),
+	}
+
+	secs := r.Interval.Seconds()
+	perSec := func(before, after uint64) float64 {
+		d, reset := p.delta(before, after)
+		if reset {
+			r.Reset = true
+		}
+		return float64(d) / secs
+	}
+
+	r.InBitsPerSec = 8 * perSec(prev.InOctets, cur.InOctets)
+	r.OutBitsPerSec = 8 * perSec(prev.OutOctets, cur.OutOctets)
+	r.InPktsPerSec = perSec(prev.InUcastPkts, cur.InUcastPkts) +
+		perSec(prev.InMcastPkts, cur.InMcastPkts) +
+		perSec(prev.InBcastPkts, cur.InBcastPkts)
+	r.OutPktsPerSec = perSec(prev.OutUcastPkts, cur.OutUcastPkts) +
+		perSec(prev.OutMcastPkts, cur.OutMcastPkts) +
+		perSec(prev.OutBcastPkts, cur.OutBcastPkts)
+	r.InErrorsPerSec = perSec(prev.InErrors, cur.InErrors)
+	r.OutErrorsPerSec = perSec(prev.OutErrors, cur.OutErrors)
+	r.InDiscardsPerSec = perSec(prev.InDiscards, cur.InDiscards)
+	r.OutDiscardsPerSec = perSec(prev.OutDiscards, cur.OutDiscards)
+
+	return r
+}
+
+// delta returns how much a counter increased between two reads.
+// A 32-bit counter that went backwards wrapped if the implied increase is below
+// half its range; any other decrease means the counters were cleared, so the
+// delta counts from zero.
+func (p *CounterPoller) delta(before, after uint64) (uint64, bool) {
+	if after >= before {
+		return after - before, false
+	}
+	if p.CounterBits == 32 && before <= math.MaxUint32 {
+		wrapped := after + (math.MaxUint32 - before) + 1
+		if wrapped < 1<<31 {
+			return wrapped, false
+		}
+	}
+	return after, true // cleared
+}
+
+func (p *CounterPoller) failed(err error) {
+	if p.OnError != nil {
+		p.OnError(err)
+		return
+	}
+	p.Client.logf("counter poller: %v", err)
+}
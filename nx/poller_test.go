@@ -0,0 +1,86 @@
+package nx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCounterPollerDelta(t *testing.T) {
+	tests := []struct {
+		bits          int
+		before, after uint64
+		want          uint64
+		reset         bool
+	}{
+		{bits: 64, before: 100, after: 150, want: 50},
+		{bits: 64, before: 100, after: 100, want: 0},
+		{bits: 64, before: math.MaxUint32, after: math.MaxUint32 + 10, want: 10},
+		{bits: 64, before: 1000, after: 10, want: 10, reset: true},
+		{bits: 0, before: math.MaxUint32 - 5, after: 4, want: 4, reset: true},
+		{bits: 32, before: 100, after: 150, want: 50},
+		{bits: 32, before: math.MaxUint32 - 5, after: 4, want: 10},
+		{bits: 32, before: math.MaxUint32, after: 0, want: 1},
+		{bits: 32, before: 1 << 31, after: 0, want: 0, reset: true},
+		{bits: 32, before: 1000, after: 10, want: 10, reset: true},
+		{bits: 32, before: math.MaxUint32 + 10, after: 5, want: 5, reset: true},
+	}
+
+	for _, tt := range tests {
+		p := &CounterPoller{CounterBits: tt.bits}
+		got, reset := p.delta(tt.before, tt.after)
+		if got != tt.want || reset != tt.reset {
+			t.Errorf("%d-bit delta(%d, %d) = %d, %v; want %d, %v", tt.bits, tt.before, tt.after, got, reset, tt.want, tt.reset)
+		}
+	}
+}
+
+func TestCounterPollerUpdate(t *testing.T) {
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	read := func(ifName string, at time.Duration, octets, pkts uint64) InterfaceCounters {
+		return InterfaceCounters{Interface: ifName, Time: t0.Add(at), InOctets: octets, InUcastPkts: pkts, OutOctets: octets}
+	}
+
+	p := &CounterPoller{}
+
+	if rates := p.update(map[string]InterfaceCounters{
+		"ethernet:1/3": read("ethernet:1/3", 0, 1000, 10),
+	}); len(rates) != 0 {
+		t.Fatalf("first update: got %d rates, want none", len(rates))
+	}
+
+	rates := p.update(map[string]InterfaceCounters{
+		"ethernet:1/3": read("ethernet:1/3", 10*time.Second, 2000, 30),
+		"ethernet:1/1": read("ethernet:1/1", 10*time.Second, 500, 5),
+	})
+	if len(rates) != 1 {
+		t.Fatalf("second update: got %d rates, want 1", len(rates))
+	}
+	r := rates[0]
+	if r.Interface != "ethernet:1/3" || r.Interval != 10*time.Second || r.Reset {
+		t.Errorf("second update: got %+v", r)
+	}
+	if r.InBitsPerSec != 800 || r.OutBitsPerSec != 800 || r.InPktsPerSec != 2 {
+		t.Errorf("second update: in %v bps, out %v bps, in %v pps; want 800, 800, 2", r.InBitsPerSec, r.OutBitsPerSec, r.InPktsPerSec)
+	}
+
+	rates = p.update(map[string]InterfaceCounters{
+		"ethernet:1/3": read("ethernet:1/3", 20*time.Second, 100, 30),
+		"ethernet:1/1": read("ethernet:1/1", 20*time.Second, 1500, 15),
+	})
+	if len(rates) != 2 || rates[0].Interface != "ethernet:1/1" || rates[1].Interface != "ethernet:1/3" {
+		t.Fatalf("third update: got %+v, want ethernet:1/1 and ethernet:1/3", rates)
+	}
+	if rates[0].Reset || rates[0].InBitsPerSec != 800 {
+		t.Errorf("third update ethernet:1/1: got %+v", rates[0])
+	}
+	if !rates[1].Reset || rates[1].InBitsPerSec != 80 || rates[1].InPktsPerSec != 0 {
+		t.Errorf("third update ethernet:1/3: got %+v, want reset at 80 bps", rates[1])
+	}
+
+	if rates := p.update(map[string]InterfaceCounters{
+		"ethernet:1/1": read("ethernet:1/1", 20*time.Second, 1600, 16),
+	}); len(rates) != 0 {
+		t.Errorf("same time update: got %d rates, want none", len(rates))
+	}
+}
@@ -0,0 +1,178 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LacpOptions holds the optional LACP settings of a port-channel.
+type LacpOptions struct {
+	MinLinks              int  // lacp min-links. Zero keeps the switch default.
+	MaxLinks              int  // lacp max-bundle. Zero keeps the switch default.
+	NoSuspendIndividual   bool // no lacp suspend-individual
+	NoGracefulConvergence bool // no lacp graceful-convergence
+}
+
+// ctrl formats the pcAggrIf ctrl attribute. fast-sel-hot-stdby is kept on,
+// as in the switch default.
+func (o LacpOptions) ctrl() string {
+	ctrl := []string{"fast-sel-hot-stdby"}
+	if !o.NoGracefulConvergence {
+		ctrl = append(ctrl, "graceful-conv")
+	}
+	if !o.NoSuspendIndividual {
+		ctrl = append(ctrl, "susp-individual")
+	}
+	return strings.Join(ctrl, ",")
+}
+
+// CreatePortChannel creates port-channel pcName (Ex: port-channel:5) with
+// channel mode PcModeActive, PcModePassive or PcModeOn.
+// With lacp nil, the LACP settings of the port-channel are left unchanged.
+// LACP options are rejected in mode PcModeOn.
+func (c *Client) CreatePortChannel(pcName string, mode string, lacp *LacpOptions) error {
+	return c.CreatePortChannelContext(context.Background(), pcName, mode, lacp)
+}
+
+// CreatePortChannelContext is like CreatePortChannel but honors ctx for cancellation and deadline.
+func (c *Client) CreatePortChannelContext(ctx context.Context, pcName string, mode string, lacp *LacpOptions) error {
+
+	pcId, err := c.portChannelID(pcName)
+	if err != nil {
+		return err
+	}
+
+	jsonPc, err := formatPcAggrBody(pcId, mode, lacp)
+	if err != nil {
+		return err
+	}
+
+	c.debugf("port-channel create: Body=%s", jsonPc)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonPc))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// formatPcAggrBody formats the json body of port-channel add/replace operations.
+func formatPcAggrBody(pcId string, mode string, lacp *LacpOptions) (string, error) {
+
+	switch mode {
+	case PcModeActive, PcModePassive:
+	case PcModeOn:
+		if lacp != nil {
+			return "", fmt.Errorf("Unexpected LACP options in port-channel mode %s", mode)
+		}
+	default:
+		return "", fmt.Errorf("Unexpected port-channel mode: %s", mode)
+	}
+
+	pc := NewMO(PcTag).Set("id", PcPfx+pcId).Set("pcMode", mode)
+	if lacp != nil {
+		pc.Set("ctrl", lacp.ctrl())
+		if lacp.MinLinks != 0 {
+			pc.Set("minLinks", strconv.Itoa(lacp.MinLinks))
+		}
+		if lacp.MaxLinks != 0 {
+			pc.Set("maxLinks", strconv.Itoa(lacp.MaxLinks))
+		}
+	}
+
+	return configBody(NewMO("interfaceEntity").AddChild(pc)), nil
+}
+
+// DeletePortChannel removes port-channel pcName (Ex: port-channel:5).
+func (c *Client) DeletePortChannel(pcName string) error {
+	return c.DeletePortChannelContext(context.Background(), pcName)
+}
+
+// DeletePortChannelContext is like DeletePortChannel but honors ctx for cancellation and deadline.
+func (c *Client) DeletePortChannelContext(ctx context.Context, pcName string) error {
+
+	pcId, err := c.portChannelID(pcName)
+	if err != nil {
+		return err
+	}
+
+	body, errDel := c.delete(ctx, fmt.Sprintf(InterfacePcURI, pcId))
+	if errDel != nil {
+		return errDel
+	}
+
+	return parseJSONError(body)
+}
+
+// AddPortChannelMember binds ethernet interface ifName (Ex: ethernet:1/3)
+// to port-channel pcName (Ex: port-channel:5).
+func (c *Client) AddPortChannelMember(pcName string, ifName string) error {
+	return c.AddPortChannelMemberContext(context.Background(), pcName, ifName)
+}
+
+// AddPortChannelMemberContext is like AddPortChannelMember but honors ctx for cancellation and deadline.
+func (c *Client) AddPortChannelMemberContext(ctx context.Context, pcName string, ifName string) error {
+
+	pcId, enetId, err := c.portChannelMemberID(pcName, ifName)
+	if err != nil {
+		return err
+	}
+
+	jsonMbr := configBody(NewMO("interfaceEntity").AddChild(
+		NewMO(PcTag).Set("id", PcPfx+pcId).AddChild(
+			NewMO("pcRsMbrIfs").Set("tDn", "sys/intf/phys-["+EnetPfx+enetId+"]"))))
+
+	c.debugf("port-channel member add: Body=%s", jsonMbr)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonMbr))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// RemovePortChannelMember unbinds ethernet interface ifName (Ex: ethernet:1/3)
+// from port-channel pcName (Ex: port-channel:5).
+func (c *Client) RemovePortChannelMember(pcName string, ifName string) error {
+	return c.RemovePortChannelMemberContext(context.Background(), pcName, ifName)
+}
+
+// RemovePortChannelMemberContext is like RemovePortChannelMember but honors ctx for cancellation and deadline.
+func (c *Client) RemovePortChannelMemberContext(ctx context.Context, pcName string, ifName string) error {
+
+	pcId, enetId, err := c.portChannelMemberID(pcName, ifName)
+	if err != nil {
+		return err
+	}
+
+	body, errDel := c.delete(ctx, fmt.Sprintf(PcMbrURI, pcId, enetId))
+	if errDel != nil {
+		return errDel
+	}
+
+	return parseJSONError(body)
+}
+
+// portChannelMemberID returns the port-channel id and ethernet id of a member.
+// Ex: port-channel:5 and ethernet:1/3 results in 5 and 1/3
+func (c *Client) portChannelMemberID(pcName string, ifName string) (string, string, error) {
+	pcId, err := c.portChannelID(pcName)
+	if err != nil {
+		return "", "", err
+	}
+	ifType, enetId, err := c.SplitInterfaceName(ifName)
+	if err != nil {
+		return "", "", err
+	}
+	if ifType != "ethernet" || enetId == "" {
+		return "", "", fmt.Errorf("Unexpected port-channel member %s. Example Value: ethernet:1/3", ifName)
+	}
+	return pcId, enetId, nil
+}
@@ -0,0 +1,65 @@
+package nx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+func TestCreatePortChannelLacp(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	c, errNew := nx.New(srv.ClientOptions())
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	defer c.Logout()
+
+	const dn = "sys/intf/aggr-[po5]"
+	attrs := func() map[string]string {
+		_, attrs, found := srv.MO(dn)
+		if !found {
+			t.Fatalf("%s not found", dn)
+		}
+		return attrs
+	}
+
+	lacp := &nx.LacpOptions{MinLinks: 2, NoSuspendIndividual: true}
+	if errCreate := c.CreatePortChannel("port-channel:5", nx.PcModeActive, lacp); errCreate != nil {
+		t.Fatalf("CreatePortChannel: %v", errCreate)
+	}
+	if a := attrs(); a["ctrl"] != "fast-sel-hot-stdby,graceful-conv" || a["minLinks"] != "2" || a["pcMode"] != nx.PcModeActive {
+		t.Errorf("with LACP options: got %v", a)
+	}
+
+	// Without LACP options, the LACP settings are left unchanged.
+	if errCreate := c.CreatePortChannel("port-channel:5", nx.PcModePassive, nil); errCreate != nil {
+		t.Fatalf("CreatePortChannel without LACP options: %v", errCreate)
+	}
+	if a := attrs(); a["ctrl"] != "fast-sel-hot-stdby,graceful-conv" || a["minLinks"] != "2" || a["pcMode"] != nx.PcModePassive {
+		t.Errorf("without LACP options: got %v", a)
+	}
+
+	before := len(srv.Requests())
+	if errCreate := c.CreatePortChannel("port-channel:6", nx.PcModeOn, lacp); errCreate == nil {
+		t.Errorf("mode on with LACP options: got no error")
+	}
+	if got := len(srv.Requests()) - before; got != 0 {
+		t.Errorf("mode on with LACP options: sent %d requests", got)
+	}
+
+	if errCreate := c.CreatePortChannel("port-channel:6", nx.PcModeOn, nil); errCreate != nil {
+		t.Fatalf("CreatePortChannel mode on: %v", errCreate)
+	}
+	for _, r := range srv.Requests()[before:] {
+		if r.Method == "POST" && strings.Contains(r.Body, `"ctrl"`) {
+			t.Errorf("mode on: sent ctrl: %s", r.Body)
+		}
+	}
+}
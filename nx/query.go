@@ -0,0 +1,133 @@
+package nx
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// QueryOptions selects the objects returned by GetDN and GetClass.
+// The zero value returns the queried object itself, without children.
+//
+// With PageSize set, objects are requested PageSize at a time starting at
+// page Page, and the pages are collected into a single result.
+// MaxPages limits how many pages are requested.
+// Ex: admin down ethernet interfaces, 100 per request
+//
+//	q := QueryOptions{
+//		Target:        QueryTargetSubtree,
+//		TargetClasses: []string{"l1PhysIf"},
+//		Filter:        Eq("l1PhysIf.adminSt", AdminDown),
+//		OrderBy:       []string{"l1PhysIf.id"},
+//		PageSize:      100,
+//	}
+//	intfs, err := c.GetDN("sys/intf", q)
+type QueryOptions struct {
+	Target         string   // query-target: QueryTargetSelf, QueryTargetChildren or QueryTargetSubtree
+	TargetClasses  []string // target-subtree-class: classes kept among Target. Ex: l1PhysIf
+	Filter         Filter   // query-target-filter: property filter of Target objects
+	RspSubtree     string   // rsp-subtree: RspSubtreeNo, RspSubtreeChildren or RspSubtreeFull
+	RspPropInclude string   // rsp-prop-include: RspPropAll, RspPropNamingOnly or RspPropConfigOnly
+	OrderBy        []string // order-by: properties, optionally followed by |asc or |desc. Ex: l1PhysIf.id|desc
+	Page           int      // page: first page requested, from 0
+	PageSize       int      // page-size: objects per request. 0 requests all objects at once.
+	MaxPages       int      // Maximum number of pages requested. 0 requests all pages.
+}
+
+// Filter is a query-target-filter expression. Ex: eq(l1PhysIf.adminSt,"up")
+type Filter string
+
+// Eq matches objects whose property prop (Ex: l1PhysIf.adminSt) equals value.
+func Eq(prop, value string) Filter {
+	return Filter(fmt.Sprintf("eq(%s,%s)", prop, strconv.Quote(value)))
+}
+
+// Wcard matches objects whose property prop (Ex: l1PhysIf.descr) matches
+// the regular expression pattern.
+func Wcard(prop, pattern string) Filter {
+	return Filter(fmt.Sprintf("wcard(%s,%s)", prop, strconv.Quote(pattern)))
+}
+
+// And matches objects matching all filters.
+func And(filters ...Filter) Filter {
+	return combine("and", filters)
+}
+
+// Or matches objects matching any of filters.
+func Or(filters ...Filter) Filter {
+	return combine("or", filters)
+}
+
+func combine(op string, filters []Filter) Filter {
+	var list []string
+	for _, f := range filters {
+		if f != "" {
+			list = append(list, string(f))
+		}
+	}
+	switch len(list) {
+	case 0:
+		return ""
+	case 1:
+		return Filter(list[0])
+	}
+	return Filter(op + "(" + strings.Join(list, ",") + ")")
+}
+
+// query returns the URI query string of q for page, with its leading "?",
+// or "" if empty. page is ignored unless q.PageSize is set.
+func (q QueryOptions) query(page int) (string, error) {
+	v := url.Values{}
+
+	switch q.Target {
+	case "":
+	case QueryTargetSelf, QueryTargetChildren, QueryTargetSubtree:
+		v.Set("query-target", q.Target)
+	default:
+		return "", fmt.Errorf("Unexpected query target: %s", q.Target)
+	}
+
+	if len(q.TargetClasses) > 0 {
+		v.Set("target-subtree-class", strings.Join(q.TargetClasses, ","))
+	}
+
+	if q.Filter != "" {
+		v.Set("query-target-filter", string(q.Filter))
+	}
+
+	switch q.RspSubtree {
+	case "":
+	case RspSubtreeNo, RspSubtreeChildren, RspSubtreeFull:
+		v.Set("rsp-subtree", q.RspSubtree)
+	default:
+		return "", fmt.Errorf("Unexpected rsp-subtree: %s", q.RspSubtree)
+	}
+
+	switch q.RspPropInclude {
+	case "":
+	case RspPropAll, RspPropNamingOnly, RspPropConfigOnly:
+		v.Set("rsp-prop-include", q.RspPropInclude)
+	default:
+		return "", fmt.Errorf("Unexpected rsp-prop-include: %s", q.RspPropInclude)
+	}
+
+	if len(q.OrderBy) > 0 {
+		v.Set("order-by", strings.Join(q.OrderBy, ","))
+	}
+
+	if q.Page < 0 || q.PageSize < 0 || q.MaxPages < 0 {
+		return "", fmt.Errorf("bad paging: page=%d page-size=%d max-pages=%d", q.Page, q.PageSize, q.MaxPages)
+	}
+	if q.PageSize > 0 {
+		v.Set("page", strconv.Itoa(page))
+		v.Set("page-size", strconv.Itoa(q.PageSize))
+	}
+
+	if len(v) == 0 {
+		return "", nil
+	}
+	// Encode escapes "+" and writes spaces as "+": spell spaces %20 instead,
+	// as in the URI path.
+	return "?" + strings.Replace(v.Encode(), "+", "%20", -1), nil
+}
@@ -0,0 +1,69 @@
+package nx
+
+import "testing"
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		f    Filter
+		want string
+	}{
+		{Eq("l2BD.adminSt", "active"), `eq(l2BD.adminSt,"active")`},
+		{Eq("l1PhysIf.descr", `to "core"`), `eq(l1PhysIf.descr,"to \"core\"")`},
+		{Wcard("l2BD.name", `^web\d`), `wcard(l2BD.name,"^web\\d")`},
+		{And(), ``},
+		{And("", Eq("l2BD.id", "10")), `eq(l2BD.id,"10")`},
+		{And(Eq("l2BD.id", "10"), Eq("l2BD.adminSt", "active")), `and(eq(l2BD.id,"10"),eq(l2BD.adminSt,"active"))`},
+		{Or(Eq("l2BD.id", "10"), And(Wcard("l2BD.name", "^web"), Eq("l2BD.adminSt", "active"))),
+			`or(eq(l2BD.id,"10"),and(wcard(l2BD.name,"^web"),eq(l2BD.adminSt,"active")))`},
+	}
+
+	for _, tt := range tests {
+		if string(tt.f) != tt.want {
+			t.Errorf("got %s, want %s", tt.f, tt.want)
+		}
+	}
+}
+
+func TestQueryOptionsQuery(t *testing.T) {
+	tests := []struct {
+		q       QueryOptions
+		page    int
+		want    string
+		wantErr bool
+	}{
+		{q: QueryOptions{}, want: ""},
+		{q: QueryOptions{Target: QueryTargetChildren}, want: "?query-target=children"},
+		{q: QueryOptions{Target: QueryTargetSubtree, TargetClasses: []string{"l1PhysIf", "pcAggrIf"}},
+			want: "?query-target=subtree&target-subtree-class=l1PhysIf%2CpcAggrIf"},
+		{q: QueryOptions{Filter: Eq("l1PhysIf.descr", "to core")},
+			want: "?query-target-filter=eq%28l1PhysIf.descr%2C%22to%20core%22%29"},
+		{q: QueryOptions{Filter: Eq("l2BD.name", "a+b")},
+			want: "?query-target-filter=eq%28l2BD.name%2C%22a%2Bb%22%29"},
+		{q: QueryOptions{RspSubtree: RspSubtreeFull, RspPropInclude: RspPropNamingOnly, OrderBy: []string{"l2BD.id|desc"}},
+			want: "?order-by=l2BD.id%7Cdesc&rsp-prop-include=naming-only&rsp-subtree=full"},
+		{q: QueryOptions{PageSize: 50}, page: 2, want: "?page=2&page-size=50"},
+		{q: QueryOptions{Page: 3}, page: 3, want: ""},
+		{q: QueryOptions{Target: "everything"}, wantErr: true},
+		{q: QueryOptions{RspSubtree: "some"}, wantErr: true},
+		{q: QueryOptions{RspPropInclude: "most"}, wantErr: true},
+		{q: QueryOptions{PageSize: -1}, wantErr: true},
+		{q: QueryOptions{Page: -1, PageSize: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.q.query(tt.page)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%+v: query(%d) = %q, want error", tt.q, tt.page, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: query(%d): %v", tt.q, tt.page, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%+v: query(%d) = %q, want %q", tt.q, tt.page, got, tt.want)
+		}
+	}
+}
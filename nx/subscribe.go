@@ -0,0 +1,352 @@
+package nx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// SubscriptionURI queries class %s and subscribes to its changes.
+	SubscriptionURI = "/api/class/%s.json?subscription=yes"
+	// SubscriptionRefreshURI keeps subscription id %s alive.
+	SubscriptionRefreshURI = "/api/subscriptionRefresh.json?id=%s"
+
+	subscriptionRefreshPeriod = 60 * time.Second // Nexus drops subscriptions not refreshed within 90s
+	eventBufferSize           = 100
+)
+
+// Event statuses reported by the switch.
+const (
+	EventCreated  = "created"
+	EventModified = "modified"
+	EventDeleted  = "deleted"
+)
+
+// Event is a managed-object change notification received through Subscribe.
+type Event struct {
+	SubscriptionID string                 // Id of the subscription that matched the change
+	Class          string                 // Object class. Ex: l1PhysIf
+	Dn             string                 // Object distinguished name. Ex: sys/intf/phys-[eth1/3]
+	Status         string                 // EventCreated, EventModified or EventDeleted
+	Attributes     map[string]interface{} // Changed attributes, as reported by the switch
+}
+
+// Subscription delivers change events for the classes given to Subscribe.
+type Subscription struct {
+	Events <-chan Event // Closed when the subscription ends. See Err().
+
+	c      *Client
+	host   int
+	ids    []string
+	events chan Event
+	stop   chan struct{}
+	done   chan struct{}
+
+	once   sync.Once    // Ends the subscription once
+	mu     sync.RWMutex // Protects closed and err against concurrent deliver
+	closed bool
+	err    error
+}
+
+// Subscribe opens the notification websocket, if not already open, and
+// subscribes to changes of the given object classes (Ex: "l1PhysIf", "l2BD").
+// Subscriptions are refreshed in background until Close is called.
+//
+// The switch starts notifying a class as soon as it answers its subscription
+// request, before Subscribe registers it: a change made while Subscribe is
+// running may be lost. Read the current state after Subscribe returns.
+func (c *Client) Subscribe(classes ...string) (*Subscription, error) {
+	return c.SubscribeContext(context.Background(), classes...)
+}
+
+// SubscribeContext is like Subscribe but honors ctx for cancellation and
+// deadline while the subscription is being set up.
+func (c *Client) SubscribeContext(ctx context.Context, classes ...string) (*Subscription, error) {
+	if len(classes) < 1 {
+		return nil, fmt.Errorf("subscribe: missing class")
+	}
+
+	host, conn, errSocket := c.openSocket(ctx)
+	if errSocket != nil {
+		return nil, errSocket
+	}
+
+	events := make(chan Event, eventBufferSize)
+	s := &Subscription{
+		Events: events,
+		c:      c,
+		host:   host,
+		events: events,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	ctx = withHost(ctx, host) // subscriptions belong to the host holding the socket
+
+	for _, class := range classes {
+		id, errSub := c.subscribeClass(ctx, class)
+		if errSub == nil {
+			s.ids = append(s.ids, id)
+			errSub = c.attach(conn, id, s)
+		}
+		if errSub != nil {
+			c.detach(s)
+			close(s.done)
+			c.closeSocketIfIdle()
+			return nil, errSub
+		}
+	}
+
+	go s.refresh()
+
+	return s, nil
+}
+
+func (c *Client) subscribeClass(ctx context.Context, class string) (string, error) {
+	body, errGet := c.get(ctx, fmt.Sprintf(SubscriptionURI, class))
+	if errGet != nil {
+		return "", errGet
+	}
+
+	var reply struct {
+		SubscriptionID string `json:"subscriptionId"`
+	}
+	if errJSON := json.Unmarshal(body, &reply); errJSON != nil {
+		return "", errJSON
+	}
+	if reply.SubscriptionID == "" {
+		return "", fmt.Errorf("subscribe: class=%s: missing subscriptionId: %s", class, string(body))
+	}
+
+	c.debugf("subscribe: class=%s id=%s", class, reply.SubscriptionID)
+
+	return reply.SubscriptionID, nil
+}
+
+// openSocket dials the notification websocket unless already open.
+// It returns the index of the host holding the socket, and the socket.
+func (c *Client) openSocket(ctx context.Context) (int, *websocket.Conn, error) {
+	c.socketMu.Lock()
+	defer c.socketMu.Unlock()
+
+	if c.socket != nil {
+		return c.socketHost, c.socket, nil
+	}
+
+	c.mu.Lock()
+	token := c.loginToken
+	c.mu.Unlock()
+	if token == "" {
+		return 0, nil, fmt.Errorf("subscribe: not logged in")
+	}
+
+	host := c.hosts.first()
+	url := makeURL("wss", c.Opt.Hosts[host], "/socket"+token)
+
+	dialer := websocket.Dialer{
+		TLSClientConfig:  c.tlsConfig(),
+		HandshakeTimeout: 10 * time.Second,
+		Jar:              c.cli.Jar,
+	}
+
+	c.debugf("subscribe: websocket: %s", makeURL("wss", c.Opt.Hosts[host], "/socket"))
+
+	conn, _, errDial := dialer.DialContext(ctx, url, nil)
+	if errDial != nil {
+		return 0, nil, fmt.Errorf("subscribe: websocket: %v", errDial)
+	}
+
+	c.socket = conn
+	c.socketHost = host
+	c.subs = map[string]*Subscription{}
+
+	go c.readSocket(conn)
+
+	return host, conn, nil
+}
+
+// readSocket dispatches notifications to subscriptions until the socket fails or is closed.
+func (c *Client) readSocket(conn *websocket.Conn) {
+	for {
+		_, msg, errRead := conn.ReadMessage()
+		if errRead != nil {
+			c.socketFailed(conn, errRead)
+			return
+		}
+
+		var note struct {
+			SubscriptionID []string                            `json:"subscriptionId"`
+			Imdata         []map[string]map[string]interface{} `json:"imdata"`
+		}
+		if errJSON := json.Unmarshal(msg, &note); errJSON != nil {
+			c.debugf("subscribe: bad notification: %v: %s", errJSON, string(msg))
+			continue
+		}
+
+		for _, id := range note.SubscriptionID {
+			c.socketMu.Lock()
+			s := c.subs[id]
+			c.socketMu.Unlock()
+			if s == nil {
+				continue
+			}
+			for _, mo := range note.Imdata {
+				for class, obj := range mo {
+					attr, _ := obj["attributes"].(map[string]interface{})
+					s.deliver(Event{
+						SubscriptionID: id,
+						Class:          class,
+						Dn:             attrString(attr, "dn"),
+						Status:         attrString(attr, "status"),
+						Attributes:     attr,
+					})
+				}
+			}
+		}
+	}
+}
+
+// socketFailed ends every subscription sharing the failed socket.
+func (c *Client) socketFailed(conn *websocket.Conn, err error) {
+	c.socketMu.Lock()
+	if c.socket != conn {
+		c.socketMu.Unlock()
+		return // socket closed on purpose
+	}
+	subs := c.subs
+	c.socket = nil
+	c.subs = nil
+	c.socketMu.Unlock()
+
+	conn.Close()
+
+	c.debugf("subscribe: websocket: %v", err)
+
+	for _, s := range subs {
+		s.end(fmt.Errorf("subscribe: websocket: %v", err))
+	}
+}
+
+// attach routes notifications of subscription id to s. It fails if conn,
+// the socket s was opened on, failed or was closed meanwhile.
+func (c *Client) attach(conn *websocket.Conn, id string, s *Subscription) error {
+	c.socketMu.Lock()
+	defer c.socketMu.Unlock()
+	if c.socket != conn {
+		return fmt.Errorf("subscribe: websocket closed")
+	}
+	c.subs[id] = s
+	return nil
+}
+
+func (c *Client) detach(s *Subscription) {
+	c.socketMu.Lock()
+	defer c.socketMu.Unlock()
+	for _, id := range s.ids {
+		delete(c.subs, id)
+	}
+}
+
+// closeSocketIfIdle closes the websocket once no subscription uses it.
+func (c *Client) closeSocketIfIdle() {
+	c.socketMu.Lock()
+	defer c.socketMu.Unlock()
+	if c.socket == nil || len(c.subs) > 0 {
+		return
+	}
+	c.socket.Close()
+	c.socket = nil
+	c.subs = nil
+}
+
+// refresh keeps the subscription ids alive on the switch.
+func (s *Subscription) refresh() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(subscriptionRefreshPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(withHost(context.Background(), s.host), subscriptionRefreshPeriod)
+		for _, id := range s.ids {
+			if _, errGet := s.c.get(ctx, fmt.Sprintf(SubscriptionRefreshURI, id)); errGet != nil {
+				s.c.logf("subscribe: refresh id=%s: %v", id, errGet)
+			}
+		}
+		cancel()
+	}
+}
+
+// deliver queues ev for the subscriber, waiting for room unless the subscription ends.
+func (s *Subscription) deliver(ev Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.events <- ev:
+	case <-s.stop:
+	}
+}
+
+// end stops the subscription and closes Events, recording err as the reason.
+func (s *Subscription) end(err error) {
+	s.once.Do(func() {
+		close(s.stop)
+
+		s.mu.Lock()
+		s.closed = true
+		s.err = err
+		close(s.events)
+		s.mu.Unlock()
+	})
+
+	<-s.done
+}
+
+// Err returns why Events was closed. It is nil while the subscription is active
+// and after a Close.
+func (s *Subscription) Err() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.err
+}
+
+// Close ends the subscription. The websocket is closed once no subscription uses it.
+func (s *Subscription) Close() error {
+	s.c.detach(s)
+	s.end(nil)
+	s.c.closeSocketIfIdle()
+	return nil
+}
+
+// closeSubscriptions ends every subscription and closes the websocket.
+func (c *Client) closeSubscriptions() {
+	c.socketMu.Lock()
+	conn, subs := c.socket, c.subs
+	c.socket = nil
+	c.subs = nil
+	c.socketMu.Unlock()
+
+	if conn == nil {
+		return
+	}
+
+	conn.Close()
+
+	for _, s := range subs {
+		s.end(nil)
+	}
+}
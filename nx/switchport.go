@@ -0,0 +1,72 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// SetSwitchportMode sets interface ifName (Ex: ethernet:1/3 or port-channel:5)
+// to switchport mode TrunkMode, AccessMode or EdgeMode.
+func (c *Client) SetSwitchportMode(ifName string, mode string) error {
+	return c.SetSwitchportModeContext(context.Background(), ifName, mode)
+}
+
+// SetSwitchportModeContext is like SetSwitchportMode but honors ctx for cancellation and deadline.
+func (c *Client) SetSwitchportModeContext(ctx context.Context, ifName string, mode string) error {
+
+	switch mode {
+	case TrunkMode, AccessMode, EdgeMode:
+	default:
+		return fmt.Errorf("Unexpected switchport mode: %s", mode)
+	}
+
+	_, id, err := c.interfaceID(ifName)
+	if err != nil {
+		return err
+	}
+
+	jsonMode := configBody(NewMO("stpEntity").AddChild(
+		NewMO("stpInst").AddChild(
+			NewMO("stpIf").Set("id", id).Set("mode", mode))))
+
+	c.debugf("switchport mode set: Body=%s", jsonMode)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonMode))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// GetSwitchportMode returns the switchport mode of interface ifName
+// (Ex: ethernet:1/3 or port-channel:5).
+func (c *Client) GetSwitchportMode(ifName string) (string, error) {
+	return c.GetSwitchportModeContext(context.Background(), ifName)
+}
+
+// GetSwitchportModeContext is like GetSwitchportMode but honors ctx for cancellation and deadline.
+func (c *Client) GetSwitchportModeContext(ctx context.Context, ifName string) (string, error) {
+
+	_, id, err := c.interfaceID(ifName)
+	if err != nil {
+		return "", err
+	}
+
+	body, errGet := c.get(ctx, fmt.Sprintf(StpIfURI, id))
+	if errGet != nil {
+		return "", errGet
+	}
+
+	list, errAttr := jsonImdataAttributes(c, body, "stpIf", "GetSwitchportMode")
+	if errAttr != nil {
+		return "", errAttr
+	}
+	if len(list) < 1 {
+		return "", notFoundError("switchport mode not found for %s", ifName)
+	}
+
+	return attrString(list[0], "mode"), nil
+}
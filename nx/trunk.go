@@ -0,0 +1,140 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// AddAllowedVlans adds vlans to the allowed vlans of trunk interface ifName
+// (Ex: ethernet:1/3 or port-channel:5).
+//
+//	switchport trunk allowed vlan add 10-20
+func (c *Client) AddAllowedVlans(ifName string, vlans VLANSet) error {
+	return c.AddAllowedVlansContext(context.Background(), ifName, vlans)
+}
+
+// AddAllowedVlansContext is like AddAllowedVlans but honors ctx for cancellation and deadline.
+func (c *Client) AddAllowedVlansContext(ctx context.Context, ifName string, vlans VLANSet) error {
+	return c.updateAllowedVlans(ctx, ifName, "+", vlans)
+}
+
+// RemoveAllowedVlans removes vlans from the allowed vlans of trunk interface ifName
+// (Ex: ethernet:1/3 or port-channel:5).
+//
+//	switchport trunk allowed vlan remove 10-20
+func (c *Client) RemoveAllowedVlans(ifName string, vlans VLANSet) error {
+	return c.RemoveAllowedVlansContext(context.Background(), ifName, vlans)
+}
+
+// RemoveAllowedVlansContext is like RemoveAllowedVlans but honors ctx for cancellation and deadline.
+func (c *Client) RemoveAllowedVlansContext(ctx context.Context, ifName string, vlans VLANSet) error {
+	return c.updateAllowedVlans(ctx, ifName, "-", vlans)
+}
+
+// SetAllowedVlans replaces the allowed vlans of trunk interface ifName
+// (Ex: ethernet:1/3 or port-channel:5). An empty set allows no vlan.
+//
+//	switchport trunk allowed vlan 10-20
+func (c *Client) SetAllowedVlans(ifName string, vlans VLANSet) error {
+	return c.SetAllowedVlansContext(context.Background(), ifName, vlans)
+}
+
+// SetAllowedVlansContext is like SetAllowedVlans but honors ctx for cancellation and deadline.
+func (c *Client) SetAllowedVlansContext(ctx context.Context, ifName string, vlans VLANSet) error {
+	if errValid := vlans.Validate(); errValid != nil {
+		return errValid
+	}
+	return c.postAllowedVlans(ctx, ifName, vlans.String())
+}
+
+// updateAllowedVlans adds (op "+") or removes (op "-") vlans from the allowed
+// vlans of ifName. The switch applies the change to its current list, so
+// updates made concurrently by other clients are kept.
+func (c *Client) updateAllowedVlans(ctx context.Context, ifName string, op string, vlans VLANSet) error {
+
+	if errValid := vlans.Validate(); errValid != nil {
+		return errValid
+	}
+	if len(vlans) == 0 {
+		return nil // nothing to change
+	}
+
+	return c.postAllowedVlans(ctx, ifName, op+vlans.String())
+}
+
+// postAllowedVlans writes allowed, a vlan list in NX-API trunk syntax
+// (Ex: 10-20, +10-20 or -10-20), to the allowed vlans of ifName.
+func (c *Client) postAllowedVlans(ctx context.Context, ifName string, allowed string) error {
+
+	ifType, ifId, err := c.SplitInterfaceName(ifName)
+	if err != nil {
+		return err
+	}
+	if ifId == "" {
+		return fmt.Errorf("missing interface id in %s", ifName)
+	}
+
+	jsonTrunk, err := c.formatTrunkBody(ifType, ifId, allowed, "")
+	if err != nil {
+		return err
+	}
+
+	return c.postTrunk(ctx, jsonTrunk)
+}
+
+// SetNativeVlan sets the native vlan of trunk interface ifName
+// (Ex: ethernet:1/3 or port-channel:5).
+//
+//	switchport trunk native vlan 129
+func (c *Client) SetNativeVlan(ifName string, vlan int) error {
+	return c.SetNativeVlanContext(context.Background(), ifName, vlan)
+}
+
+// SetNativeVlanContext is like SetNativeVlan but honors ctx for cancellation and deadline.
+func (c *Client) SetNativeVlanContext(ctx context.Context, ifName string, vlan int) error {
+	if vlan < MinVlan || vlan > MaxVlan {
+		return fmt.Errorf("bad native vlan %d: expected %d-%d", vlan, MinVlan, MaxVlan)
+	}
+	return c.postNativeVlan(ctx, ifName, vlan)
+}
+
+// ClearNativeVlan restores the default native vlan 1 of trunk interface ifName
+// (Ex: ethernet:1/3 or port-channel:5).
+//
+//	no switchport trunk native vlan
+func (c *Client) ClearNativeVlan(ifName string) error {
+	return c.ClearNativeVlanContext(context.Background(), ifName)
+}
+
+// ClearNativeVlanContext is like ClearNativeVlan but honors ctx for cancellation and deadline.
+func (c *Client) ClearNativeVlanContext(ctx context.Context, ifName string) error {
+	return c.postNativeVlan(ctx, ifName, 1)
+}
+
+func (c *Client) postNativeVlan(ctx context.Context, ifName string, vlan int) error {
+
+	tag, id, err := c.interfaceID(ifName)
+	if err != nil {
+		return err
+	}
+
+	jsonTrunk := configBody(NewMO("interfaceEntity").AddChild(
+		NewMO(tag).Set("id", id).Set("mode", TrunkMode).Set("nativeVlan", "vlan-"+strconv.Itoa(vlan))))
+
+	return c.postTrunk(ctx, jsonTrunk)
+}
+
+func (c *Client) postTrunk(ctx context.Context, jsonTrunk string) error {
+
+	c.debugf("trunk vlan set: Body=%s", jsonTrunk)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonTrunk))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
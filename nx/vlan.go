@@ -2,25 +2,132 @@ package nx
 
 import (
         "bytes"
+        "context"
         "fmt"
+        "strconv"
+        "strings"
 )
 
+// AddVlan creates vlan vlanId, optionally mapped to VxLAN segment vni.
+// vlanId must be a vlan id from MinVlan to MaxVlan: other ids are rejected
+// without a request to the switch.
 func (c *Client) AddVlan(vlanId string, vni string) error {
+    return c.AddVlanContext(context.Background(), vlanId, vni)
+}
 
-    var segment string
+// AddVlanContext is like AddVlan but honors ctx for cancellation and deadline.
+func (c *Client) AddVlanContext(ctx context.Context, vlanId string, vni string) error {
+    return c.AddVlanWithOptionsContext(ctx, vlanId, VlanOptions{Vni: vni})
+}
 
-    if vni !=  "" {
-        segment = fmt.Sprintf(vxlanSegment, vni)
-    } else {
-        segment = ""
+// VlanOptions holds the optional vlan settings of AddVlanWithOptions and UpdateVlan.
+// Empty fields leave the corresponding setting unchanged, or at its default on creation.
+type VlanOptions struct {
+    Name    *string // Vlan name. Pointer to empty string clears the name.
+    AdminSt string  // VlanActive or VlanSuspend. Defaults to VlanActive on creation.
+    Mode    string  // VlanModeCE or VlanModeFabricPath
+    Vni     string  // VxLAN segment id, or NoVni to remove the segment
+}
+
+// AddVlanWithOptions creates vlan vlanId with settings opts.
+// vlanId is checked as in AddVlan.
+func (c *Client) AddVlanWithOptions(vlanId string, opts VlanOptions) error {
+    return c.AddVlanWithOptionsContext(context.Background(), vlanId, opts)
+}
+
+// AddVlanWithOptionsContext is like AddVlanWithOptions but honors ctx for cancellation and deadline.
+func (c *Client) AddVlanWithOptionsContext(ctx context.Context, vlanId string, opts VlanOptions) error {
+
+    if opts.AdminSt == "" {
+        opts.AdminSt = VlanActive
     }
-      
-    result := fmt.Sprintf(vlanEntity, vlanId, segment)
 
-    jsonVlan := TopBegin+result+TopEnd
-    c.debugf("vlan add: Body=%s", jsonVlan)
+    attrs, err := formatVlanAttrs(opts)
+    if err != nil {
+        return err
+    }
+
+    attrs["pcTag"] = "1"
+
+    return c.postVlan(ctx, "vlan add", vlanId, attrs)
+}
+
+// UpdateVlan changes the settings of existing vlan vlanId, for example to
+// rename it, suspend it or change its VxLAN segment, without deleting it.
+func (c *Client) UpdateVlan(vlanId string, opts VlanOptions) error {
+    return c.UpdateVlanContext(context.Background(), vlanId, opts)
+}
+
+// UpdateVlanContext is like UpdateVlan but honors ctx for cancellation and deadline.
+func (c *Client) UpdateVlanContext(ctx context.Context, vlanId string, opts VlanOptions) error {
+
+    attrs, err := formatVlanAttrs(opts)
+    if err != nil {
+        return err
+    }
+    if len(attrs) < 1 {
+        return fmt.Errorf("empty vlan update for vlan %s", vlanId)
+    }
+
+    resp, errGet := c.GetVlanContext(ctx, vlanId)
+    if errGet != nil {
+        return errGet
+    }
+    if len(resp) < 1 {
+        return notFoundError("vlan %s not found", vlanId)
+    }
+
+    return c.postVlan(ctx, "vlan update", vlanId, attrs)
+}
+
+// formatVlanAttrs returns the l2BD attributes set in opts.
+func formatVlanAttrs(opts VlanOptions) (map[string]string, error) {
+    attrs := map[string]string{}
+
+    if opts.Name != nil {
+        attrs["name"] = *opts.Name
+    }
+
+    switch opts.AdminSt {
+    case "":
+    case VlanActive, VlanSuspend:
+        attrs["adminSt"] = opts.AdminSt
+    default:
+        return nil, fmt.Errorf("Unexpected vlan admin state: %s", opts.AdminSt)
+    }
+
+    switch opts.Mode {
+    case "":
+    case VlanModeCE, VlanModeFabricPath:
+        attrs["mode"] = opts.Mode
+    default:
+        return nil, fmt.Errorf("Unexpected vlan mode: %s", opts.Mode)
+    }
 
-    body, errPost := c.post(ConfigRootURI, contentTypeJSON,
+    switch opts.Vni {
+    case "":
+    case NoVni:
+        attrs["accEncap"] = "unknown"
+    default:
+        if _, errConv := strconv.Atoi(opts.Vni); errConv != nil {
+            return nil, fmt.Errorf("bad vni '%s': %v", opts.Vni, errConv)
+        }
+        attrs["accEncap"] = "vxlan-" + opts.Vni
+    }
+
+    return attrs, nil
+}
+
+func (c *Client) postVlan(ctx context.Context, label string, vlanId string, attrs map[string]string) error {
+
+    if n, errConv := strconv.Atoi(vlanId); errConv != nil || n < MinVlan || n > MaxVlan {
+        return fmt.Errorf("bad vlan id '%s': expected %d-%d", vlanId, MinVlan, MaxVlan)
+    }
+
+    jsonVlan := configBody(NewMO("bdEntity").AddChild(newL2BDMO(vlanId, attrs)))
+    c.debugf("%s: Body=%s", label, jsonVlan)
+
+    body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
                             bytes.NewBufferString(jsonVlan))
     if errPost != nil {
         return errPost
@@ -29,8 +136,23 @@ func (c *Client) AddVlan(vlanId string, vni string) error {
     return parseJSONError(body)
 }
 
+// newL2BDMO returns the l2BD object of vlan vlanId with attributes attrs.
+func newL2BDMO(vlanId string, attrs map[string]string) *MO {
+    bd := NewMO("l2BD").Set("fabEncap", "vlan-"+vlanId)
+    for k, v := range attrs {
+        bd.Set(k, v)
+    }
+    return bd
+}
 
+
+// GetVlan returns the attributes of vlan id, or of all vlans if id is empty.
 func (c *Client) GetVlan(id string) ([]map[string]interface{}, error) {
+    return c.GetVlanContext(context.Background(), id)
+}
+
+// GetVlanContext is like GetVlan but honors ctx for cancellation and deadline.
+func (c *Client) GetVlanContext(ctx context.Context, id string) ([]map[string]interface{}, error) {
     var uri string
 
     if id == "" {
@@ -39,7 +161,7 @@ func (c *Client) GetVlan(id string) ([]map[string]interface{}, error) {
         uri = fmt.Sprintf(VlanURI, id)
     }
 
-    body, errGet := c.get(uri)
+    body, errGet := c.get(ctx, uri)
     if errGet != nil {
             return nil, errGet
     }
@@ -48,12 +170,18 @@ func (c *Client) GetVlan(id string) ([]map[string]interface{}, error) {
 
 }
 
+// DeleteVlan removes vlan id.
 func (c *Client) DeleteVlan(id string) (error) {
+    return c.DeleteVlanContext(context.Background(), id)
+}
+
+// DeleteVlanContext is like DeleteVlan but honors ctx for cancellation and deadline.
+func (c *Client) DeleteVlanContext(ctx context.Context, id string) (error) {
     var uri string
 
     uri = fmt.Sprintf(VlanURI, id)
 
-    body, errDel := c.delete(uri)
+    body, errDel := c.delete(ctx, uri)
     if errDel != nil {
             return errDel
     }
@@ -61,3 +189,55 @@ func (c *Client) DeleteVlan(id string) (error) {
     return parseJSONError(body)
 }
 
+// L2BD holds the attributes of a vlan bridge domain (l2BD).
+type L2BD struct {
+    ID       int    // Vlan id
+    Dn       string // Distinguished name. Ex: sys/bd/bd-[vlan-10]
+    Name     string // Configured vlan name
+    OperName string // Operational vlan name. Ex: VLAN0010
+    FabEncap string // Fabric encapsulation. Ex: vlan-10
+    AccEncap string // Access encapsulation. Ex: vxlan-70010 or unknown
+    Vni      int    // VxLAN segment id. 0 if unset
+    AdminSt  string // Admin state: active or suspend
+    OperSt   string // Oper state: up or down
+    Mode     string // Vlan mode: CE or FabricPath
+}
+
+func newL2BD(m map[string]interface{}) L2BD {
+    vni, _ := strconv.Atoi(strings.TrimPrefix(attrString(m, "accEncap"), "vxlan-"))
+    return L2BD{
+        ID:       attrInt(m, "id"),
+        Dn:       attrString(m, "dn"),
+        Name:     attrString(m, "name"),
+        OperName: attrString(m, "BdOperName"),
+        FabEncap: attrString(m, "fabEncap"),
+        AccEncap: attrString(m, "accEncap"),
+        Vni:      vni,
+        AdminSt:  attrString(m, "adminSt"),
+        OperSt:   attrString(m, "operSt"),
+        Mode:     attrString(m, "mode"),
+    }
+}
+
+// GetL2BD returns typed vlans.
+// id is the vlan id or empty for all vlans.
+func (c *Client) GetL2BD(id string) ([]L2BD, error) {
+    return c.GetL2BDContext(context.Background(), id)
+}
+
+// GetL2BDContext is like GetL2BD but honors ctx for cancellation and deadline.
+func (c *Client) GetL2BDContext(ctx context.Context, id string) ([]L2BD, error) {
+
+    resp, errGet := c.GetVlanContext(ctx, id)
+    if errGet != nil {
+        return nil, errGet
+    }
+
+    result := make([]L2BD, 0, len(resp))
+    for _, m := range resp {
+        result = append(result, newL2BD(m))
+    }
+
+    return result, nil
+}
+
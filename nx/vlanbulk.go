@@ -0,0 +1,131 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const defaultBulkChunkSize = 200
+
+// BulkResult is the outcome of one request of a bulk operation.
+type BulkResult struct {
+	Vlans VLANSet // Vlans carried by the request
+	Err   error   // Nil if the request succeeded
+}
+
+// BulkReport holds one BulkResult per request of a bulk operation.
+type BulkReport []BulkResult
+
+// Failed returns the results of requests that failed.
+func (r BulkReport) Failed() []BulkResult {
+	var failed []BulkResult
+	for _, res := range r {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err summarizes the failed requests in a single error, or returns nil if all succeeded.
+func (r BulkReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(failed))
+	for _, res := range failed {
+		msgs = append(msgs, fmt.Sprintf("vlans %s: %v", res.Vlans, res.Err))
+	}
+	return fmt.Errorf("%d of %d bulk requests failed: %s", len(failed), len(r), strings.Join(msgs, "; "))
+}
+
+// AddVlans creates all vlans with settings opts, sending at most
+// ClientOptions.BulkChunkSize vlans per request.
+// The report details the outcome of each request; the error is non-nil if any failed.
+func (c *Client) AddVlans(vlans VLANSet, opts VlanOptions) (BulkReport, error) {
+	return c.AddVlansContext(context.Background(), vlans, opts)
+}
+
+// AddVlansContext is like AddVlans but honors ctx for cancellation and deadline.
+func (c *Client) AddVlansContext(ctx context.Context, vlans VLANSet, opts VlanOptions) (BulkReport, error) {
+
+	if opts.AdminSt == "" {
+		opts.AdminSt = VlanActive
+	}
+
+	attrs, err := formatVlanAttrs(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs["pcTag"] = "1"
+
+	return c.bulkVlans(ctx, "vlan bulk add", vlans, attrs)
+}
+
+// DeleteVlans removes all vlans, sending at most ClientOptions.BulkChunkSize
+// vlans per request.
+// The report details the outcome of each request; the error is non-nil if any failed.
+func (c *Client) DeleteVlans(vlans VLANSet) (BulkReport, error) {
+	return c.DeleteVlansContext(context.Background(), vlans)
+}
+
+// DeleteVlansContext is like DeleteVlans but honors ctx for cancellation and deadline.
+func (c *Client) DeleteVlansContext(ctx context.Context, vlans VLANSet) (BulkReport, error) {
+	return c.bulkVlans(ctx, "vlan bulk delete", vlans, map[string]string{"status": "deleted"})
+}
+
+// bulkVlans posts one bdEntity body per chunk of vlans, each l2BD carrying attrs.
+func (c *Client) bulkVlans(ctx context.Context, label string, vlans VLANSet, attrs map[string]string) (BulkReport, error) {
+
+	vlans = NewVLANSet(vlans...) // sorted, without duplicates
+	if len(vlans) < 1 {
+		return nil, fmt.Errorf("%s: empty vlan set", label)
+	}
+	if errValid := vlans.Validate(); errValid != nil {
+		return nil, errValid
+	}
+
+	size := c.Opt.BulkChunkSize
+	if size <= 0 {
+		size = defaultBulkChunkSize
+	}
+
+	var report BulkReport
+
+	for start := 0; start < len(vlans); start += size {
+		end := start + size
+		if end > len(vlans) {
+			end = len(vlans)
+		}
+		chunk := vlans[start:end]
+
+		bd := NewMO("bdEntity")
+		for _, v := range chunk {
+			bd.AddChild(newL2BDMO(strconv.Itoa(v), attrs))
+		}
+		jsonVlans := configBody(bd)
+
+		c.debugf("%s: vlans=%s", label, chunk)
+
+		res := BulkResult{Vlans: chunk}
+		body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+			bytes.NewBufferString(jsonVlans))
+		if errPost != nil {
+			res.Err = errPost
+		} else {
+			res.Err = parseJSONError(body)
+		}
+		report = append(report, res)
+
+		if ctx.Err() != nil {
+			break // canceled: skip remaining chunks
+		}
+	}
+
+	return report, report.Err()
+}
@@ -0,0 +1,139 @@
+package nx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Valid vlan id range.
+const (
+	MinVlan = 1
+	MaxVlan = 4094
+)
+
+// VLANSet is a set of vlan ids, formatted in NX-OS range syntax.
+// Ex: "1-10,20,30-40"
+// NewVLANSet and ParseVLANSet return sorted sets without duplicates. The
+// methods also accept literals in any order and with duplicates, such as
+// VLANSet{30, 10, 10}.
+type VLANSet []int
+
+// NewVLANSet returns the set holding ids, sorted and without duplicates.
+func NewVLANSet(ids ...int) VLANSet {
+	set := append(VLANSet(nil), ids...)
+	sort.Ints(set)
+	return set.dedup()
+}
+
+// ParseVLANSet parses NX-OS vlan range syntax. Ex: "1-10,20,30-40".
+// Empty string and "none" yield an empty set.
+func ParseVLANSet(s string) (VLANSet, error) {
+	var set VLANSet
+
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "none") {
+		return set, nil
+	}
+
+	for _, r := range strings.Split(s, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(r), "-", 2)
+		first, errFirst := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if errFirst != nil {
+			return nil, fmt.Errorf("bad vlan range '%s': %v", r, errFirst)
+		}
+		last := first
+		if len(bounds) == 2 {
+			var errLast error
+			last, errLast = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if errLast != nil {
+				return nil, fmt.Errorf("bad vlan range '%s': %v", r, errLast)
+			}
+		}
+		if first > last {
+			return nil, fmt.Errorf("bad vlan range '%s': first > last", r)
+		}
+		if first < MinVlan || last > MaxVlan {
+			return nil, fmt.Errorf("bad vlan range '%s': expected %d-%d", r, MinVlan, MaxVlan)
+		}
+		for v := first; v <= last; v++ {
+			set = append(set, v)
+		}
+	}
+
+	sort.Ints(set)
+
+	return set.dedup(), nil
+}
+
+// dedup removes duplicates from a sorted set.
+func (s VLANSet) dedup() VLANSet {
+	if len(s) < 2 {
+		return s
+	}
+	out := s[:1]
+	for _, v := range s[1:] {
+		if v != out[len(out)-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// String formats the set in NX-OS range syntax. Ex: "1-10,20,30-40".
+// An empty set yields "".
+func (s VLANSet) String() string {
+	s = NewVLANSet(s...)
+	var ranges []string
+	for i := 0; i < len(s); {
+		j := i
+		for j+1 < len(s) && s[j+1] == s[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(s[i]))
+		} else {
+			ranges = append(ranges, strconv.Itoa(s[i])+"-"+strconv.Itoa(s[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
+
+// Contains reports whether vlan id belongs to the set.
+func (s VLANSet) Contains(id int) bool {
+	for _, v := range s {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns the vlans found in s or o.
+func (s VLANSet) Union(o VLANSet) VLANSet {
+	return NewVLANSet(append(append([]int(nil), s...), o...)...)
+}
+
+// Difference returns the vlans found in s but not in o.
+func (s VLANSet) Difference(o VLANSet) VLANSet {
+	o = NewVLANSet(o...)
+	var out VLANSet
+	for _, v := range NewVLANSet(s...) {
+		if i := sort.SearchInts(o, v); i == len(o) || o[i] != v {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Validate checks every vlan id is within MinVlan-MaxVlan.
+func (s VLANSet) Validate() error {
+	for _, v := range s {
+		if v < MinVlan || v > MaxVlan {
+			return fmt.Errorf("bad vlan %d: expected %d-%d", v, MinVlan, MaxVlan)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package nx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVLANSet(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    VLANSet
+		str     string
+		wantErr bool
+	}{
+		{in: "", want: nil, str: ""},
+		{in: "none", want: nil, str: ""},
+		{in: "10", want: VLANSet{10}, str: "10"},
+		{in: "1-3,5", want: VLANSet{1, 2, 3, 5}, str: "1-3,5"},
+		{in: " 30-31 , 10,20 ", want: VLANSet{10, 20, 30, 31}, str: "10,20,30-31"},
+		{in: "5,4,3,5", want: VLANSet{3, 4, 5}, str: "3-5"},
+		{in: "1-10,20,30-40", want: NewVLANSet(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 20, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40), str: "1-10,20,30-40"},
+		{in: "4094", want: VLANSet{4094}, str: "4094"},
+		{in: "0", wantErr: true},
+		{in: "4095", wantErr: true},
+		{in: "10-5", wantErr: true},
+		{in: "a", wantErr: true},
+		{in: "1-b", wantErr: true},
+		{in: "garbage", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVLANSet(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseVLANSet(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseVLANSet(%q): %v", tt.in, err)
+			continue
+		}
+		if len(got) != 0 || len(tt.want) != 0 {
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseVLANSet(%q) = %v, want %v", tt.in, []int(got), []int(tt.want))
+			}
+		}
+		if s := got.String(); s != tt.str {
+			t.Errorf("ParseVLANSet(%q).String() = %q, want %q", tt.in, s, tt.str)
+		}
+	}
+}
+
+func TestVLANSetUnsorted(t *testing.T) {
+	set := VLANSet{30, 10, 20, 10}
+
+	if s := set.String(); s != "10,20,30" {
+		t.Errorf("String() = %q, want 10,20,30", s)
+	}
+	for _, id := range []int{10, 20, 30} {
+		if !set.Contains(id) {
+			t.Errorf("Contains(%d) = false", id)
+		}
+	}
+	if set.Contains(15) {
+		t.Errorf("Contains(15) = true")
+	}
+
+	cur := NewVLANSet(10, 20, 30)
+	if got := cur.Difference(VLANSet{30, 10}).String(); got != "20" {
+		t.Errorf("Difference = %q, want 20", got)
+	}
+	if got := (VLANSet{30, 10, 20}).Difference(VLANSet{20}).String(); got != "10,30" {
+		t.Errorf("unsorted Difference = %q, want 10,30", got)
+	}
+	if got := (VLANSet{5}).Union(VLANSet{3, 4, 3}).String(); got != "3-5" {
+		t.Errorf("Union = %q, want 3-5", got)
+	}
+}
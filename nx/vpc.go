@@ -0,0 +1,164 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VpcIf holds a vpc attached to a port-channel (vpcIf).
+type VpcIf struct {
+	ID          int    // Vpc id
+	Dn          string // Distinguished name. Ex: sys/vpc/inst/dom/if-19
+	PortChannel string // Port-channel in SplitInterfaceName form. Ex: port-channel:19
+
+	Consistency VpcConsistency // Per-vpc consistency-check result
+}
+
+// AddPortChannelVpc attaches port-channel pcName (Ex: port-channel:19) to vpc vpcId.
+//
+//	int port-channel 19
+//	    vpc 19
+func (c *Client) AddPortChannelVpc(pcName string, vpcId string) error {
+	return c.AddPortChannelVpcContext(context.Background(), pcName, vpcId)
+}
+
+// AddPortChannelVpcContext is like AddPortChannelVpc but honors ctx for cancellation and deadline.
+func (c *Client) AddPortChannelVpcContext(ctx context.Context, pcName string, vpcId string) error {
+
+	pcId, err := c.portChannelID(pcName)
+	if err != nil {
+		return err
+	}
+
+	if errID := checkVpcID(vpcId); errID != nil {
+		return errID
+	}
+
+	jsonVpc := configBody(NewMO("vpcEntity").AddChild(
+		NewMO("vpcInst").AddChild(
+			NewMO("vpcDom").AddChild(
+				NewMO("vpcIf").Set("id", vpcId).AddChild(
+					NewMO("vpcRsVpcConf").Set("tDn", "sys/intf/aggr-["+PcPfx+pcId+"]"))))))
+
+	c.debugf("port-channel vpc add: Body=%s", jsonVpc)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonVpc))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// maxVpcID is the highest vpc number of a port-channel.
+const maxVpcID = 4096
+
+// checkVpcID validates a vpc number, 1-maxVpcID.
+func checkVpcID(vpcId string) error {
+	n, errConv := strconv.Atoi(vpcId)
+	if errConv != nil {
+		return fmt.Errorf("bad vpc id '%s': %v", vpcId, errConv)
+	}
+	if n < 1 || n > maxVpcID {
+		return fmt.Errorf("bad vpc id '%s': expected 1-%d", vpcId, maxVpcID)
+	}
+	return nil
+}
+
+// RemovePortChannelVpc removes vpc vpcId from its port-channel.
+func (c *Client) RemovePortChannelVpc(vpcId string) error {
+	return c.RemovePortChannelVpcContext(context.Background(), vpcId)
+}
+
+// RemovePortChannelVpcContext is like RemovePortChannelVpc but honors ctx for cancellation and deadline.
+func (c *Client) RemovePortChannelVpcContext(ctx context.Context, vpcId string) error {
+
+	if errID := checkVpcID(vpcId); errID != nil {
+		return errID
+	}
+
+	body, errDel := c.delete(ctx, fmt.Sprintf(VpcIfDnURI, vpcId))
+	if errDel != nil {
+		return errDel
+	}
+
+	return parseJSONError(body)
+}
+
+// GetVpcInterfaces returns vpc vpcId, or all vpcs attached to port-channels if vpcId is empty.
+func (c *Client) GetVpcInterfaces(vpcId string) ([]VpcIf, error) {
+	return c.GetVpcInterfacesContext(context.Background(), vpcId)
+}
+
+// GetVpcInterfacesContext is like GetVpcInterfaces but honors ctx for cancellation and deadline.
+func (c *Client) GetVpcInterfacesContext(ctx context.Context, vpcId string) ([]VpcIf, error) {
+
+	uri := AllVpcIfURI
+	if vpcId != "" {
+		if errID := checkVpcID(vpcId); errID != nil {
+			return nil, errID
+		}
+		uri = fmt.Sprintf(VpcIfURI, vpcId)
+	}
+
+	body, errGet := c.get(ctx, uri)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	list, errParse := ParseImdata(body)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	result := make([]VpcIf, 0, len(list))
+	for _, mo := range list {
+		if mo.Class != "vpcIf" {
+			continue
+		}
+		v := VpcIf{
+			ID: mo.attrInt("id"),
+			Dn: mo.Attr("dn"),
+			Consistency: VpcConsistency{
+				Status: mo.Attr("compatSt"),
+				Reason: mo.Attr("compatQualStr"),
+			},
+		}
+		for _, conf := range mo.ChildrenOf("vpcRsVpcConf") {
+			v.PortChannel = interfaceFromDn(conf.Attr("tDn"))
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+// portChannelID returns the id of port-channel pcName. Ex: port-channel:19 results in 19
+func (c *Client) portChannelID(pcName string) (string, error) {
+	ifType, id, err := c.SplitInterfaceName(pcName)
+	if err != nil {
+		return "", err
+	}
+	if ifType != "port-channel" || id == "" {
+		return "", fmt.Errorf("Unexpected port-channel name %s. Example Value: port-channel:5", pcName)
+	}
+	return id, nil
+}
+
+// portChannelFromDn converts a port-channel dn into SplitInterfaceName form.
+// Ex: sys/intf/aggr-[po19] results in port-channel:19
+func portChannelFromDn(dn string) string {
+	i := strings.Index(dn, "aggr-[po")
+	if i < 0 {
+		return ""
+	}
+	id := dn[i+len("aggr-[po"):]
+	if j := strings.Index(id, "]"); j >= 0 {
+		id = id[:j]
+	}
+	return joinInterfaceName("port-channel", id)
+}
@@ -0,0 +1,112 @@
+package nx_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+func TestVpcInterfaces(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	c, errNew := nx.New(srv.ClientOptions())
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	defer c.Logout()
+
+	for _, id := range []string{"19", "4096"} {
+		if errAdd := c.AddPortChannelVpc("port-channel:"+id, id); errAdd != nil {
+			t.Fatalf("AddPortChannelVpc %s: %v", id, errAdd)
+		}
+	}
+	for _, id := range []string{"0", "4097", "x"} {
+		if errAdd := c.AddPortChannelVpc("port-channel:19", id); errAdd == nil {
+			t.Errorf("AddPortChannelVpc %s: got no error", id)
+		}
+	}
+
+	list, errGet := c.GetVpcInterfaces("")
+	if errGet != nil {
+		t.Fatalf("GetVpcInterfaces: %v", errGet)
+	}
+	if len(list) != 2 {
+		t.Fatalf("GetVpcInterfaces: got %+v, want 2 vpcs", list)
+	}
+	for _, v := range list {
+		if want := fmt.Sprintf("port-channel:%d", v.ID); v.PortChannel != want {
+			t.Errorf("vpc %d: got port-channel %q, want %q", v.ID, v.PortChannel, want)
+		}
+	}
+
+	one, errOne := c.GetVpcInterfaces("19")
+	if errOne != nil {
+		t.Fatalf("GetVpcInterfaces 19: %v", errOne)
+	}
+	if len(one) != 1 || one[0].ID != 19 || one[0].Dn != "sys/vpc/inst/dom/if-19" || one[0].PortChannel != "port-channel:19" {
+		t.Errorf("GetVpcInterfaces 19: got %+v", one)
+	}
+
+	if errRemove := c.RemovePortChannelVpc("19"); errRemove != nil {
+		t.Fatalf("RemovePortChannelVpc: %v", errRemove)
+	}
+	if list, errGet := c.GetVpcInterfaces(""); errGet != nil || len(list) != 1 || list[0].ID != 4096 {
+		t.Errorf("GetVpcInterfaces after remove: got %+v, %v", list, errGet)
+	}
+}
+
+func TestVpcDomain(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	c, errNew := nx.New(srv.ClientOptions())
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	defer c.Logout()
+
+	// Domain ids stop at 1000, below the vpc number range.
+	for _, id := range []int{0, 1001, 4096} {
+		if errSet := c.SetVpcDomain(nx.VpcDomainConfig{ID: id}); errSet == nil {
+			t.Errorf("SetVpcDomain %d: got no error", id)
+		}
+	}
+
+	gw := true
+	cfg := nx.VpcDomainConfig{
+		ID:            1000,
+		RolePriority:  100,
+		PeerGateway:   &gw,
+		KeepaliveDest: "10.0.0.2",
+		KeepaliveSrc:  "10.0.0.1",
+		PeerLink:      "port-channel:1",
+	}
+	if errSet := c.SetVpcDomain(cfg); errSet != nil {
+		t.Fatalf("SetVpcDomain: %v", errSet)
+	}
+
+	d, errGet := c.GetVpcDomain()
+	if errGet != nil {
+		t.Fatalf("GetVpcDomain: %v", errGet)
+	}
+	if d.ID != 1000 || d.RolePriority != 100 || !d.PeerGateway || d.KeepaliveDest != "10.0.0.2" ||
+		d.KeepaliveSrc != "10.0.0.1" || d.KeepaliveVrf != "management" || d.PeerLink != "port-channel:1" {
+		t.Errorf("GetVpcDomain: got %+v", d)
+	}
+
+	if errDel := c.DeleteVpcDomain(); errDel != nil {
+		t.Fatalf("DeleteVpcDomain: %v", errDel)
+	}
+	if _, errGet := c.GetVpcDomain(); !nx.IsNotFound(errGet) {
+		t.Errorf("GetVpcDomain after delete: got %v, want not found", errGet)
+	}
+}
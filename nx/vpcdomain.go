@@ -0,0 +1,195 @@
+package nx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxVpcDomainID is the highest vpc domain id. Unlike vpc numbers, checked
+// by checkVpcID up to maxVpcID, domain ids stop at 1000.
+const maxVpcDomainID = 1000
+
+// VpcDomainConfig describes the vpc domain to create or update with SetVpcDomain.
+type VpcDomainConfig struct {
+	ID             int    // Vpc domain id, 1-1000
+	RolePriority   int    // Role priority. Zero keeps the switch default.
+	SystemPriority int    // System priority. Zero keeps the switch default.
+	PeerGateway    *bool  // Enable or disable peer-gateway. Nil leaves it unchanged.
+	KeepaliveDest  string // Peer-keepalive destination address. Empty skips peer-keepalive.
+	KeepaliveSrc   string // Peer-keepalive source address
+	KeepaliveVrf   string // Peer-keepalive vrf. Defaults to management.
+	PeerLink       string // Peer-link port-channel. Ex: port-channel:1. Empty skips peer-link.
+}
+
+// VpcDomain holds the configuration and operational state of the vpc domain (vpcDom).
+type VpcDomain struct {
+	ID             int    // Vpc domain id
+	Dn             string // Distinguished name. Ex: sys/vpc/inst/dom
+	RolePriority   int    // Configured role priority
+	SystemPriority int    // Configured system priority
+	PeerGateway    bool   // Peer-gateway enabled
+	KeepaliveDest  string // Peer-keepalive destination address
+	KeepaliveSrc   string // Peer-keepalive source address
+	KeepaliveVrf   string // Peer-keepalive vrf
+	PeerLink       string // Peer-link port-channel. Ex: port-channel:1
+
+	PeerStatus      string // Peer status. Ex: peer-ok
+	OperRole        string // Operational role. Ex: primary, secondary
+	DualActive      string // Dual-active detection status
+	KeepaliveStatus string // Peer-keepalive status
+	PeerLinkStatus  string // Peer-link operational state. Ex: up
+
+	Consistency VpcConsistency // Global consistency-check result
+}
+
+// VpcConsistency holds a vpc consistency-check result.
+type VpcConsistency struct {
+	Status string // Ex: consistent, inconsistent
+	Reason string // Why the check failed. Empty if consistent.
+}
+
+// Consistent reports whether the consistency check passed.
+func (v VpcConsistency) Consistent() bool {
+	return v.Status == "consistent" || v.Status == "success"
+}
+
+// SetVpcDomain creates the vpc domain, or updates the existing one.
+func (c *Client) SetVpcDomain(cfg VpcDomainConfig) error {
+	return c.SetVpcDomainContext(context.Background(), cfg)
+}
+
+// SetVpcDomainContext is like SetVpcDomain but honors ctx for cancellation and deadline.
+func (c *Client) SetVpcDomainContext(ctx context.Context, cfg VpcDomainConfig) error {
+
+	jsonDom, err := c.formatVpcDomainBody(cfg)
+	if err != nil {
+		return err
+	}
+
+	c.debugf("vpc domain set: Body=%s", jsonDom)
+
+	body, errPost := c.post(ctx, ConfigRootURI, contentTypeJSON,
+		bytes.NewBufferString(jsonDom))
+	if errPost != nil {
+		return errPost
+	}
+
+	return parseJSONError(body)
+}
+
+// formatVpcDomainBody formats the json body of vpc domain add/replace operations.
+func (c *Client) formatVpcDomainBody(cfg VpcDomainConfig) (string, error) {
+
+	if cfg.ID < 1 || cfg.ID > maxVpcDomainID {
+		return "", fmt.Errorf("bad vpc domain id %d: expected 1-%d", cfg.ID, maxVpcDomainID)
+	}
+
+	dom := NewMO("vpcDom").Set("id", strconv.Itoa(cfg.ID))
+	if cfg.RolePriority != 0 {
+		dom.Set("rolePrio", strconv.Itoa(cfg.RolePriority))
+	}
+	if cfg.SystemPriority != 0 {
+		dom.Set("sysPrio", strconv.Itoa(cfg.SystemPriority))
+	}
+	switch {
+	case cfg.PeerGateway == nil:
+	case *cfg.PeerGateway:
+		dom.Set("peerGw", "enabled")
+	default:
+		dom.Set("peerGw", "disabled")
+	}
+
+	if cfg.KeepaliveDest != "" {
+		vrf := cfg.KeepaliveVrf
+		if vrf == "" {
+			vrf = "management"
+		}
+		dom.AddChild(NewMO("vpcKeepalive").Set("destIp", cfg.KeepaliveDest).
+			Set("srcIp", cfg.KeepaliveSrc).Set("vrf", vrf))
+	}
+	if cfg.PeerLink != "" {
+		pcId, err := c.portChannelID(cfg.PeerLink)
+		if err != nil {
+			return "", err
+		}
+		dom.AddChild(NewMO("vpcPeerLink").Set("id", PcPfx+pcId))
+	}
+
+	return configBody(NewMO("vpcEntity").AddChild(NewMO("vpcInst").AddChild(dom))), nil
+}
+
+// DeleteVpcDomain removes the vpc domain.
+func (c *Client) DeleteVpcDomain() error {
+	return c.DeleteVpcDomainContext(context.Background())
+}
+
+// DeleteVpcDomainContext is like DeleteVpcDomain but honors ctx for cancellation and deadline.
+func (c *Client) DeleteVpcDomainContext(ctx context.Context) error {
+
+	body, errDel := c.delete(ctx, VpcDomURI)
+	if errDel != nil {
+		return errDel
+	}
+
+	return parseJSONError(body)
+}
+
+// GetVpcDomain returns the vpc domain with its peer status and consistency-check result.
+func (c *Client) GetVpcDomain() (*VpcDomain, error) {
+	return c.GetVpcDomainContext(context.Background())
+}
+
+// GetVpcDomainContext is like GetVpcDomain but honors ctx for cancellation and deadline.
+func (c *Client) GetVpcDomainContext(ctx context.Context) (*VpcDomain, error) {
+
+	body, errGet := c.get(ctx, VpcDomSubtreeURI)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	list, errParse := ParseImdata(body)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	for _, mo := range list {
+		if mo.Class == "vpcDom" {
+			return newVpcDomain(mo), nil
+		}
+	}
+
+	return nil, notFoundError("vpc domain not found")
+}
+
+func newVpcDomain(m *MO) *VpcDomain {
+	d := &VpcDomain{
+		ID:             m.attrInt("id"),
+		Dn:             m.Attr("dn"),
+		RolePriority:   m.attrInt("rolePrio"),
+		SystemPriority: m.attrInt("sysPrio"),
+		PeerGateway:    m.Attr("peerGw") == "enabled",
+		PeerStatus:     m.Attr("peerSt"),
+		OperRole:       m.Attr("operRole"),
+		DualActive:     m.Attr("dualActiveSt"),
+		Consistency: VpcConsistency{
+			Status: m.Attr("compatSt"),
+			Reason: m.Attr("compatQualStr"),
+		},
+	}
+
+	for _, ka := range m.ChildrenOf("vpcKeepalive") {
+		d.KeepaliveDest = ka.Attr("destIp")
+		d.KeepaliveSrc = ka.Attr("srcIp")
+		d.KeepaliveVrf = ka.Attr("vrf")
+		d.KeepaliveStatus = ka.Attr("operSt")
+	}
+	for _, pl := range m.ChildrenOf("vpcPeerLink") {
+		d.PeerLink = joinInterfaceName("port-channel", strings.TrimPrefix(pl.Attr("id"), PcPfx))
+		d.PeerLinkStatus = pl.Attr("operSt")
+	}
+
+	return d
+}
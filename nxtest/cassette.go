@@ -0,0 +1,224 @@
+package nxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Placeholders written to cassettes in place of secrets.
+const (
+	ScrubbedUser     = "USER"
+	ScrubbedPassword = "SCRUBBED"
+	ScrubbedToken    = "TOKEN"
+)
+
+var (
+	scrubUser   = regexp.MustCompile(`"(name|userName)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+	scrubPwd    = regexp.MustCompile(`"pwd"\s*:\s*"(?:[^"\\]|\\.)*"`)
+	scrubToken  = regexp.MustCompile(`"(token|sessionId|urlToken)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+	scrubCookie = regexp.MustCompile(`^\s*([^=;]+)=[^;]*`)
+)
+
+// Interaction is one request/response exchange stored in a cassette.
+type Interaction struct {
+	Method   string              `json:"method"`           // HTTP method. Ex: POST
+	URI      string              `json:"uri"`              // Request URI without host. Ex: /api/mo/sys/bd.json?query-target=children
+	Body     string              `json:"body,omitempty"`   // Scrubbed request body
+	Status   int                 `json:"status"`           // HTTP status of the reply
+	Header   map[string][]string `json:"header,omitempty"` // Content-Type and scrubbed Set-Cookie of the reply
+	Response string              `json:"response"`         // Scrubbed reply body
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that forwards requests to a real switch
+// and records every exchange, for later replay by a Replayer.
+// Usernames, passwords, login tokens, session ids and the values of all
+// cookies set by the switch are scrubbed before recording.
+//
+//	rec := nxtest.NewRecorder("testdata/vlan.json", nx.NewTransport(opt))
+//	opt.Transport = rec
+//	c, _ := nx.New(opt)
+//	...
+//	rec.Save()
+type Recorder struct {
+	cassette string
+	next     http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecorder creates a Recorder sending requests through next and storing
+// them into the cassette file on Save.
+func NewRecorder(cassette string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{cassette: cassette, next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, errBody := readRequestBody(req)
+	if errBody != nil {
+		return nil, errBody
+	}
+
+	resp, errNext := r.next.RoundTrip(req)
+	if errNext != nil {
+		return nil, errNext
+	}
+
+	reply, errRead := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if errRead != nil {
+		return nil, errRead
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(reply))
+
+	header := map[string][]string{}
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		header["Content-Type"] = []string{ct}
+	}
+	for _, ck := range resp.Header["Set-Cookie"] {
+		header["Set-Cookie"] = append(header["Set-Cookie"], scrubCookie.ReplaceAllString(ck, "$1="+ScrubbedToken))
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Method:   req.Method,
+		URI:      req.URL.RequestURI(),
+		Body:     scrub(body),
+		Status:   resp.StatusCode,
+		Header:   header,
+		Response: scrub(reply),
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	c := cassetteFile{Interactions: append([]Interaction{}, r.interactions...)}
+	r.mu.Unlock()
+
+	buf, errJSON := json.MarshalIndent(c, "", "  ")
+	if errJSON != nil {
+		return errJSON
+	}
+	return ioutil.WriteFile(r.cassette, append(buf, '\n'), 0644)
+}
+
+// Replayer is an http.RoundTripper answering requests from a cassette
+// written by Recorder, without any network access.
+// Each request is matched to the first unused interaction with the same
+// method, URI and scrubbed body; the host is ignored, so any ClientOptions.Hosts
+// value works. Unmatched requests fail with an error.
+//
+//	rep, _ := nxtest.NewReplayer("testdata/vlan.json")
+//	c, _ := nx.New(nx.ClientOptions{Hosts: []string{"replay"}, User: "admin", Pass: "x", Transport: rep})
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer loads a cassette file.
+func NewReplayer(cassette string) (*Replayer, error) {
+	buf, errRead := ioutil.ReadFile(cassette)
+	if errRead != nil {
+		return nil, errRead
+	}
+	var c cassetteFile
+	if errJSON := json.Unmarshal(buf, &c); errJSON != nil {
+		return nil, fmt.Errorf("Cassette %s: %s", cassette, errJSON)
+	}
+	return &Replayer{interactions: c.Interactions, used: make([]bool, len(c.Interactions))}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, errBody := readRequestBody(req)
+	if errBody != nil {
+		return nil, errBody
+	}
+	uri := req.URL.RequestURI()
+	scrubbed := scrub(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, it := range r.interactions {
+		if r.used[i] || it.Method != req.Method || it.URI != uri || it.Body != scrubbed {
+			continue
+		}
+		r.used[i] = true
+		header := http.Header{}
+		for k, v := range it.Header {
+			header[k] = append([]string{}, v...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", it.Status, http.StatusText(it.Status)),
+			StatusCode:    it.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(it.Response)),
+			ContentLength: int64(len(it.Response)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Unexpected request, not in cassette: %s %s %s", req.Method, uri, scrubbed)
+}
+
+// Unused returns the interactions not replayed yet.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var list []Interaction
+	for i, it := range r.interactions {
+		if !r.used[i] {
+			list = append(list, it)
+		}
+	}
+	return list
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, errRead := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if errRead != nil {
+		return nil, errRead
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// scrub replaces secrets with placeholders. Usernames are only scrubbed
+// from aaaUser and aaaLogin objects, since other objects use name for
+// configuration such as vlan names.
+func scrub(buf []byte) string {
+	s := string(buf)
+	if strings.Contains(s, `"aaaUser"`) || strings.Contains(s, `"aaaLogin"`) {
+		s = scrubUser.ReplaceAllString(s, `"$1":"`+ScrubbedUser+`"`)
+	}
+	s = scrubPwd.ReplaceAllString(s, `"pwd":"`+ScrubbedPassword+`"`)
+	return scrubToken.ReplaceAllString(s, `"$1":"`+ScrubbedToken+`"`)
+}
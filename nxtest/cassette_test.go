@@ -0,0 +1,136 @@
+package nxtest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+// secretSpy collects the secrets handed out by the switch behind next: the
+// value of every cookie set and the session fields of aaaLogin replies.
+// It also sets an extra cookie on every reply.
+type secretSpy struct {
+	next http.RoundTripper
+
+	mu      sync.Mutex
+	n       int
+	secrets []string
+}
+
+func (s *secretSpy) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, errTrip := s.next.RoundTrip(req)
+	if errTrip != nil {
+		return nil, errTrip
+	}
+	body, errRead := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if errRead != nil {
+		return nil, errRead
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.n++
+	resp.Header.Add("Set-Cookie", fmt.Sprintf("nxapi_auth=spy-secret-%d; Path=/", s.n))
+
+	for _, ck := range resp.Cookies() {
+		s.secrets = append(s.secrets, ck.Value)
+	}
+
+	var reply struct {
+		Imdata []struct {
+			AaaLogin struct {
+				Attributes map[string]string `json:"attributes"`
+			} `json:"aaaLogin"`
+		} `json:"imdata"`
+	}
+	if json.Unmarshal(body, &reply) == nil {
+		for _, obj := range reply.Imdata {
+			for _, field := range []string{"token", "sessionId"} {
+				if v := obj.AaaLogin.Attributes[field]; v != "" {
+					s.secrets = append(s.secrets, v)
+				}
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// session runs logins, a session expiry and a few vlan requests through opt.
+func session(t *testing.T, opt nx.ClientOptions, srv *nxtest.Server) {
+	t.Helper()
+	c := login(t, srv, opt)
+	if errAdd := c.AddVlan("10", ""); errAdd != nil {
+		t.Fatalf("AddVlan: %v", errAdd)
+	}
+	if srv != nil {
+		srv.ExpireSessions()
+	}
+	if _, errGet := c.GetL2BD("10"); errGet != nil {
+		t.Fatalf("GetL2BD after session expired: %v", errGet)
+	}
+	c.Logout()
+}
+
+func TestRecorderScrubsSecrets(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	dir, errDir := ioutil.TempDir("", "cassette")
+	if errDir != nil {
+		t.Fatal(errDir)
+	}
+	defer os.RemoveAll(dir)
+	cassette := filepath.Join(dir, "vlan.json")
+
+	opt := srv.ClientOptions()
+	spy := &secretSpy{next: nx.NewTransport(opt)}
+	rec := nxtest.NewRecorder(cassette, spy)
+	opt.Transport = rec
+	session(t, opt, srv)
+	if errSave := rec.Save(); errSave != nil {
+		t.Fatal(errSave)
+	}
+
+	buf, errRead := ioutil.ReadFile(cassette)
+	if errRead != nil {
+		t.Fatal(errRead)
+	}
+	saved := string(buf)
+
+	if len(spy.secrets) == 0 {
+		t.Fatal("no secret handed out")
+	}
+	for _, secret := range append(spy.secrets, nxtest.DefaultPass, `\"`+nxtest.DefaultUser+`\"`) {
+		if strings.Contains(saved, secret) {
+			t.Errorf("cassette holds secret %s", secret)
+		}
+	}
+	if !strings.Contains(saved, "nxapi_auth="+nxtest.ScrubbedToken) {
+		t.Errorf("cassette misses scrubbed nxapi_auth cookie")
+	}
+
+	// The scrubbed cassette still replays the session.
+	rep, errRep := nxtest.NewReplayer(cassette)
+	if errRep != nil {
+		t.Fatal(errRep)
+	}
+	opt.Transport = rep
+	session(t, opt, nil)
+	if unused := rep.Unused(); len(unused) != 0 {
+		t.Errorf("replay: %d interactions unused", len(unused))
+	}
+}
@@ -0,0 +1,286 @@
+package nxtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// writeQuery answers a query with the objects found, applying
+// query-target-filter, order-by, page, page-size, rsp-subtree and
+// rsp-prop-include. totalCount counts the objects of all pages.
+func writeQuery(w http.ResponseWriter, q url.Values, found []*mo) {
+	if expr := q.Get("query-target-filter"); expr != "" {
+		match, errParse := parseFilter(expr)
+		if errParse != nil {
+			writeError(w, http.StatusBadRequest, "400", errParse.Error())
+			return
+		}
+		var out []*mo
+		for _, m := range found {
+			if match(m) {
+				out = append(out, m)
+			}
+		}
+		found = out
+	}
+
+	if orderBy := q.Get("order-by"); orderBy != "" {
+		if errOrder := sortMos(found, orderBy); errOrder != nil {
+			writeError(w, http.StatusBadRequest, "400", errOrder.Error())
+			return
+		}
+	}
+
+	total := len(found)
+
+	if size := q.Get("page-size"); size != "" {
+		pageSize, errSize := strconv.Atoi(size)
+		page, errPage := strconv.Atoi(q.Get("page"))
+		if q.Get("page") == "" {
+			page, errPage = 0, nil
+		}
+		if errSize != nil || errPage != nil || pageSize < 1 || page < 0 {
+			writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("bad paging: page=%s page-size=%s", q.Get("page"), size))
+			return
+		}
+		start, end := page*pageSize, (page+1)*pageSize
+		if start > len(found) {
+			start = len(found)
+		}
+		if end > len(found) {
+			end = len(found)
+		}
+		found = found[start:end]
+	}
+
+	imdata := encodeAll(found, q.Get("rsp-subtree"))
+	if q.Get("rsp-prop-include") == "naming-only" {
+		for i, m := range found {
+			for _, obj := range imdata[i].(map[string]interface{}) {
+				attrs := obj.(map[string]interface{})["attributes"].(map[string]interface{})
+				for k := range attrs {
+					if !m.isNaming(k) {
+						delete(attrs, k)
+					}
+				}
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"totalCount": fmt.Sprint(total),
+		"imdata":     imdata,
+	})
+}
+
+// isNaming reports whether attribute attr is dn, rn or part of the
+// relative name of m.
+func (m *mo) isNaming(attr string) bool {
+	return attr == "dn" || attr == "rn" || strings.Contains(rnFormats[m.class], "{"+attr+"}")
+}
+
+// sortMos sorts list by order-by properties. Ex: l1PhysIf.id|desc,l1PhysIf.mtu
+func sortMos(list []*mo, orderBy string) error {
+	type key struct {
+		class, attr string
+		desc        bool
+	}
+	var keys []key
+	for _, spec := range strings.Split(orderBy, ",") {
+		prop, order := spec, "asc"
+		if i := strings.Index(spec, "|"); i >= 0 {
+			prop, order = spec[:i], spec[i+1:]
+		}
+		class, attr, errProp := splitProp(prop)
+		if errProp != nil {
+			return errProp
+		}
+		if order != "asc" && order != "desc" {
+			return fmt.Errorf("bad order-by %s: unexpected order %s", spec, order)
+		}
+		keys = append(keys, key{class: class, attr: attr, desc: order == "desc"})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		for _, k := range keys {
+			a, b := list[i].attrOf(k.class, k.attr), list[j].attrOf(k.class, k.attr)
+			if a == b {
+				continue
+			}
+			return (a < b) != k.desc
+		}
+		return false
+	})
+
+	return nil
+}
+
+// attrOf returns attribute attr of m if m is of class, or "".
+func (m *mo) attrOf(class, attr string) string {
+	if m.class != class {
+		return ""
+	}
+	return m.attrs[attr]
+}
+
+func splitProp(prop string) (string, string, error) {
+	i := strings.Index(prop, ".")
+	if i <= 0 || i == len(prop)-1 {
+		return "", "", fmt.Errorf("bad property %s: expected class.attribute", prop)
+	}
+	return prop[:i], prop[i+1:], nil
+}
+
+// parseFilter parses a query-target-filter expression made of
+// eq, ne, wcard, and, or and not. Ex: and(eq(l2BD.adminSt,"active"),wcard(l2BD.name,"^web"))
+func parseFilter(expr string) (func(*mo) bool, error) {
+	p := &filterParser{s: expr}
+	match, errParse := p.expr()
+	if errParse != nil {
+		return nil, errParse
+	}
+	if p.i != len(p.s) {
+		return nil, fmt.Errorf("bad filter %s: unexpected %s", expr, p.s[p.i:])
+	}
+	return match, nil
+}
+
+type filterParser struct {
+	s string
+	i int
+}
+
+func (p *filterParser) expr() (func(*mo) bool, error) {
+	open := strings.Index(p.s[p.i:], "(")
+	if open < 0 {
+		return nil, fmt.Errorf("bad filter %s: missing (", p.s)
+	}
+	op := strings.TrimSpace(p.s[p.i : p.i+open])
+	p.i += open + 1
+
+	switch op {
+	case "and", "or", "not":
+		var list []func(*mo) bool
+		for {
+			match, errExpr := p.expr()
+			if errExpr != nil {
+				return nil, errExpr
+			}
+			list = append(list, match)
+			if p.next(",") {
+				continue
+			}
+			if p.next(")") {
+				break
+			}
+			return nil, fmt.Errorf("bad filter %s: expected , or ) at %d", p.s, p.i)
+		}
+		return combineFilters(op, list)
+	case "eq", "ne", "wcard":
+		return p.compare(op)
+	}
+
+	return nil, fmt.Errorf("bad filter %s: unsupported operator %s", p.s, op)
+}
+
+func combineFilters(op string, list []func(*mo) bool) (func(*mo) bool, error) {
+	switch op {
+	case "and":
+		return func(m *mo) bool {
+			for _, match := range list {
+				if !match(m) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "or":
+		return func(m *mo) bool {
+			for _, match := range list {
+				if match(m) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+	if len(list) != 1 {
+		return nil, fmt.Errorf("bad filter: not expects one operand, found %d", len(list))
+	}
+	return func(m *mo) bool { return !list[0](m) }, nil
+}
+
+func (p *filterParser) compare(op string) (func(*mo) bool, error) {
+	comma := strings.Index(p.s[p.i:], ",")
+	if comma < 0 {
+		return nil, fmt.Errorf("bad filter %s: missing value of %s", p.s, op)
+	}
+	class, attr, errProp := splitProp(strings.TrimSpace(p.s[p.i : p.i+comma]))
+	if errProp != nil {
+		return nil, errProp
+	}
+	p.i += comma + 1
+
+	value, errValue := p.quoted()
+	if errValue != nil {
+		return nil, errValue
+	}
+	if !p.next(")") {
+		return nil, fmt.Errorf("bad filter %s: missing ) at %d", p.s, p.i)
+	}
+
+	switch op {
+	case "eq":
+		return func(m *mo) bool { return m.class == class && m.attrs[attr] == value }, nil
+	case "ne":
+		return func(m *mo) bool { return m.class == class && m.attrs[attr] != value }, nil
+	}
+	re, errRe := regexp.Compile(value)
+	if errRe != nil {
+		return nil, fmt.Errorf("bad filter %s: %v", p.s, errRe)
+	}
+	return func(m *mo) bool { return m.class == class && re.MatchString(m.attrs[attr]) }, nil
+}
+
+// quoted reads a double quoted string, with backslash escapes.
+func (p *filterParser) quoted() (string, error) {
+	p.skipSpace()
+	if p.i >= len(p.s) || p.s[p.i] != '"' {
+		return "", fmt.Errorf("bad filter %s: expected quoted value at %d", p.s, p.i)
+	}
+	for j := p.i + 1; j < len(p.s); j++ {
+		switch p.s[j] {
+		case '\\':
+			j++
+		case '"':
+			value, errQuote := strconv.Unquote(p.s[p.i : j+1])
+			if errQuote != nil {
+				return "", fmt.Errorf("bad filter %s: %v", p.s, errQuote)
+			}
+			p.i = j + 1
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("bad filter %s: unterminated value", p.s)
+}
+
+// next consumes token tok if it comes next.
+func (p *filterParser) next(tok string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.s[p.i:], tok) {
+		p.i += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) skipSpace() {
+	for p.i < len(p.s) && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
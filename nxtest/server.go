@@ -0,0 +1,430 @@
+// Package nxtest provides an in-process fake of the Nexus NX-API REST server,
+// so code using nx.Client can be unit tested without a switch.
+//
+// The fake implements aaaLogin, aaaRefresh and aaaLogout, merges objects
+// posted to /api/mo.json or /api/mo/<dn>.json into an in-memory
+// managed-object tree, answers /api/mo/<dn>.json and /api/class/<class>.json
+// queries with query-target, target-subtree-class, query-target-filter,
+// rsp-subtree, rsp-prop-include, order-by and paging, and deletes objects
+// with DELETE.
+// Websocket notifications are not supported.
+//
+//	srv := nxtest.NewServer()
+//	defer srv.Close()
+//
+//	c, _ := nx.New(srv.ClientOptions())
+//	c.Login()
+//	c.AddVlan("10", "")
+package nxtest
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caboucha/nxgo/nx"
+)
+
+// Default credentials accepted by the fake.
+const (
+	DefaultUser = "admin"
+	DefaultPass = "password"
+)
+
+const (
+	cookieName            = "APIC-cookie"
+	defaultRefreshTimeout = 600 * time.Second
+)
+
+// Request records a request received by the fake.
+type Request struct {
+	Method string // HTTP method. Ex: POST
+	URI    string // Request URI, with query. Ex: /api/mo/sys/bd.json?query-target=children
+	Body   string // Request body
+}
+
+// Server is a fake Nexus switch serving NX-API over HTTPS.
+type Server struct {
+	User           string        // Username accepted by aaaLogin
+	Pass           string        // Password accepted by aaaLogin
+	RefreshTimeout time.Duration // Session timeout reported by aaaLogin and aaaRefresh, in whole seconds
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	root     *mo
+	tokens   map[string]bool
+	requests []Request
+}
+
+// NewServer starts a fake switch holding an empty topSystem.
+// It accepts DefaultUser and DefaultPass unless User and Pass are changed,
+// and reports sessions lasting 600s unless RefreshTimeout is changed.
+func NewServer() *Server {
+	s := &Server{
+		User:           DefaultUser,
+		Pass:           DefaultPass,
+		RefreshTimeout: defaultRefreshTimeout,
+		root:           &mo{attrs: map[string]string{}, children: map[string]*mo{}},
+		tokens:         map[string]bool{},
+	}
+	s.root.children["sys"] = newMo(s.root, "topSystem", "sys")
+	s.srv = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Close shuts the fake down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Host returns the host:port the fake listens on, for use in ClientOptions.Hosts.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.srv.URL, "https://")
+}
+
+// ClientOptions returns options for nx.New that reach the fake,
+// trusting its self-signed certificate.
+func (s *Server) ClientOptions() nx.ClientOptions {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.srv.Certificate())
+	return nx.ClientOptions{
+		Hosts:   []string{s.Host()},
+		User:    s.User,
+		Pass:    s.Pass,
+		RootCAs: pool,
+	}
+}
+
+// AddMO creates or updates the object of class at dn with attrs, creating
+// missing parents as untyped containers. Use it to seed objects the switch
+// owns, like ethernet interfaces.
+// Ex: srv.AddMO("sys/intf/phys-[eth1/3]", "l1PhysIf", map[string]string{"id": "eth1/3"})
+func (s *Server) AddMO(dn string, class string, attrs map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node := s.root
+	parts := splitDn(dn)
+	for i, rn := range parts {
+		child := node.children[rn]
+		if child == nil {
+			childClass := ""
+			if i == len(parts)-1 {
+				childClass = class
+			}
+			child = newMo(node, childClass, rn)
+			node.children[rn] = child
+		}
+		node = child
+	}
+	node.class = class
+	for k, v := range defaults[class] {
+		if _, found := node.attrs[k]; !found {
+			node.attrs[k] = v
+		}
+	}
+	for k, v := range attrs {
+		node.attrs[k] = v
+	}
+}
+
+// MO returns the class and attributes of the object at dn.
+func (s *Server) MO(dn string) (string, map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node := s.root.lookup(dn)
+	if node == nil {
+		return "", nil, false
+	}
+	attrs := make(map[string]string, len(node.attrs))
+	for k, v := range node.attrs {
+		attrs[k] = v
+	}
+	return node.class, attrs, true
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ExpireSessions invalidates all login tokens, as a session timeout would.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]bool{}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, _ := ioutil.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, URI: r.URL.RequestURI(), Body: string(payload)})
+
+	path := r.URL.Path
+
+	switch path {
+	case "/api/aaaLogin.json":
+		s.login(w, payload)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusForbidden, "403", "Token was invalid (Error: Token timeout)")
+		return
+	}
+
+	switch {
+	case path == "/api/aaaRefresh.json":
+		s.refresh(w, r)
+	case path == "/api/aaaLogout.json":
+		s.logout(w, r)
+	case path == "/api/subscriptionRefresh.json":
+		writeImdata(w, nil)
+	case path == "/api/mo.json" && r.Method == "POST":
+		s.post(w, payload)
+	case strings.HasPrefix(path, "/api/mo/") && r.Method == "POST":
+		s.postDn(w, strings.TrimSuffix(strings.TrimPrefix(path, "/api/mo/"), ".json"), payload)
+	case strings.HasPrefix(path, "/api/mo/") && r.Method == "GET":
+		s.getDn(w, r, strings.TrimSuffix(strings.TrimPrefix(path, "/api/mo/"), ".json"))
+	case strings.HasPrefix(path, "/api/mo/") && r.Method == "DELETE":
+		s.deleteDn(w, strings.TrimSuffix(strings.TrimPrefix(path, "/api/mo/"), ".json"))
+	case strings.HasPrefix(path, "/api/class/") && r.Method == "GET":
+		s.getClass(w, r, strings.TrimSuffix(strings.TrimPrefix(path, "/api/class/"), ".json"))
+	default:
+		writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("unsupported request %s %s", r.Method, path))
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	ck, errCookie := r.Cookie(cookieName)
+	return errCookie == nil && s.tokens[ck.Value]
+}
+
+func (s *Server) login(w http.ResponseWriter, payload []byte) {
+	var req struct {
+		AaaUser struct {
+			Attributes struct {
+				Name string `json:"name"`
+				Pwd  string `json:"pwd"`
+			} `json:"attributes"`
+		} `json:"aaaUser"`
+	}
+	if errJSON := json.Unmarshal(payload, &req); errJSON != nil {
+		writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("malformed login: %v", errJSON))
+		return
+	}
+	if req.AaaUser.Attributes.Name != s.User || req.AaaUser.Attributes.Pwd != s.Pass {
+		writeError(w, http.StatusUnauthorized, "401", "Username or password is incorrect")
+		return
+	}
+
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	token := hex.EncodeToString(buf)
+	s.tokens[token] = true
+
+	s.writeLogin(w, token)
+}
+
+func (s *Server) refresh(w http.ResponseWriter, r *http.Request) {
+	ck, _ := r.Cookie(cookieName)
+	s.writeLogin(w, ck.Value)
+}
+
+func (s *Server) logout(w http.ResponseWriter, r *http.Request) {
+	ck, _ := r.Cookie(cookieName)
+	delete(s.tokens, ck.Value)
+	writeImdata(w, nil)
+}
+
+// writeLogin answers aaaLogin and aaaRefresh with the session token,
+// as the cookie and in the reply, along with a new session id.
+func (s *Server) writeLogin(w http.ResponseWriter, token string) {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+
+	http.SetCookie(w, &http.Cookie{Name: cookieName, Value: token, Path: "/"})
+	writeImdata(w, []interface{}{map[string]interface{}{
+		"aaaLogin": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"token":                 token,
+				"sessionId":             base64.StdEncoding.EncodeToString(buf),
+				"userName":              s.User,
+				"refreshTimeoutSeconds": fmt.Sprint(int(s.RefreshTimeout.Seconds())),
+			},
+		},
+	}})
+}
+
+func (s *Server) post(w http.ResponseWriter, payload []byte) {
+	var objs map[string]body
+	if errJSON := json.Unmarshal(payload, &objs); errJSON != nil {
+		writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("malformed body: %v", errJSON))
+		return
+	}
+	for class, b := range objs {
+		if errMerge := s.root.merge(class, b.attrs(), b.Children); errMerge != nil {
+			writeError(w, http.StatusBadRequest, "400", errMerge.Error())
+			return
+		}
+	}
+	writeImdata(w, nil)
+}
+
+// postDn merges a posted object below the parent of dn.
+func (s *Server) postDn(w http.ResponseWriter, dn string, payload []byte) {
+	parts := splitDn(strings.Trim(dn, "/"))
+	parent := s.root
+	if len(parts) > 1 {
+		parent = s.root.lookup(strings.Join(parts[:len(parts)-1], "/"))
+	}
+	if parent == nil {
+		writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("parent of %s not found", dn))
+		return
+	}
+
+	var objs map[string]body
+	if errJSON := json.Unmarshal(payload, &objs); errJSON != nil {
+		writeError(w, http.StatusBadRequest, "400", fmt.Sprintf("malformed body: %v", errJSON))
+		return
+	}
+	for class, b := range objs {
+		attrs := b.attrs()
+		attrs["rn"] = parts[len(parts)-1]
+		if errMerge := parent.merge(class, attrs, b.Children); errMerge != nil {
+			writeError(w, http.StatusBadRequest, "400", errMerge.Error())
+			return
+		}
+	}
+	writeImdata(w, nil)
+}
+
+func (s *Server) deleteDn(w http.ResponseWriter, dn string) {
+	node := s.root.lookup(dn)
+	if node != nil && node.parent != nil {
+		delete(node.parent.children, node.rn)
+	}
+	writeImdata(w, nil)
+}
+
+func (s *Server) getDn(w http.ResponseWriter, r *http.Request, dn string) {
+	node := s.root.lookup(dn)
+	if node == nil {
+		writeImdata(w, nil)
+		return
+	}
+
+	q := r.URL.Query()
+
+	var found []*mo
+	switch q.Get("query-target") {
+	case "", "self":
+		found = []*mo{node}
+	case "children":
+		found = node.sortedChildren()
+	case "subtree":
+		node.walk(func(m *mo) { found = append(found, m) })
+	default:
+		writeError(w, http.StatusBadRequest, "400", "bad query-target: "+q.Get("query-target"))
+		return
+	}
+
+	if classes := q.Get("target-subtree-class"); classes != "" && q.Get("query-target") != "" {
+		found = filterClass(found, strings.Split(classes, ","))
+	}
+
+	writeQuery(w, q, found)
+}
+
+func (s *Server) getClass(w http.ResponseWriter, r *http.Request, class string) {
+	var found []*mo
+	s.root.walk(func(m *mo) {
+		if m.class == class {
+			found = append(found, m)
+		}
+	})
+
+	q := r.URL.Query()
+
+	if q.Get("subscription") == "yes" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"totalCount":     fmt.Sprint(len(found)),
+			"subscriptionId": "1",
+			"imdata":         encodeAll(found, q.Get("rsp-subtree")),
+		})
+		return
+	}
+
+	writeQuery(w, q, found)
+}
+
+func filterClass(list []*mo, classes []string) []*mo {
+	var out []*mo
+	for _, m := range list {
+		for _, class := range classes {
+			if m.class == strings.TrimSpace(class) {
+				out = append(out, m)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func encodeAll(list []*mo, rspSubtree string) []interface{} {
+	depth := 0
+	switch rspSubtree {
+	case "children":
+		depth = 1
+	case "full":
+		depth = -1
+	}
+	imdata := make([]interface{}, 0, len(list))
+	for _, m := range list {
+		imdata = append(imdata, m.encode(depth))
+	}
+	return imdata
+}
+
+func writeImdata(w http.ResponseWriter, imdata []interface{}) {
+	if imdata == nil {
+		imdata = []interface{}{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"totalCount": fmt.Sprint(len(imdata)),
+		"imdata":     imdata,
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, code, text string) {
+	writeJSON(w, status, map[string]interface{}{
+		"totalCount": "1",
+		"imdata": []interface{}{map[string]interface{}{
+			"error": map[string]interface{}{
+				"attributes": map[string]interface{}{"code": code, "text": text},
+			},
+		}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,337 @@
+package nxtest_test
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caboucha/nxgo/nx"
+	"github.com/caboucha/nxgo/nxtest"
+)
+
+// login returns a client logged in to srv.
+func login(t *testing.T, srv *nxtest.Server, opt nx.ClientOptions) *nx.Client {
+	t.Helper()
+	c, errNew := nx.New(opt)
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := c.Login(); errLogin != nil {
+		t.Fatal(errLogin)
+	}
+	return c
+}
+
+// count returns how many requests received by srv have method and a URI starting with prefix.
+func count(srv *nxtest.Server, method, prefix string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == method && strings.HasPrefix(r.URI, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestLogin(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	opt := srv.ClientOptions()
+	opt.Pass = "wrong"
+	bad, errNew := nx.New(opt)
+	if errNew != nil {
+		t.Fatal(errNew)
+	}
+	if errLogin := bad.Login(); !nx.IsAuthError(errLogin) {
+		t.Errorf("bad password: got %v, want auth error", errLogin)
+	}
+
+	c := login(t, srv, srv.ClientOptions())
+	defer c.Logout()
+
+	if _, errGet := c.GetVlan(""); errGet != nil {
+		t.Fatalf("GetVlan: %v", errGet)
+	}
+	logins := count(srv, "POST", "/api/aaaLogin.json")
+
+	// An expired session is renewed by logging in again, then the request is retried.
+	srv.ExpireSessions()
+	if errAdd := c.AddVlan("10", ""); errAdd != nil {
+		t.Fatalf("AddVlan after session expired: %v", errAdd)
+	}
+	if got := count(srv, "POST", "/api/aaaLogin.json") - logins; got != 1 {
+		t.Errorf("session expired: %d logins, want 1", got)
+	}
+	if _, _, found := srv.MO("sys/bd/bd-[vlan-10]"); !found {
+		t.Errorf("vlan 10 not created after session expired")
+	}
+}
+
+func TestRoundRobinSessionExpiry(t *testing.T) {
+	srv0, srv1 := nxtest.NewServer(), nxtest.NewServer()
+	defer srv0.Close()
+	defer srv1.Close()
+
+	// Cookies are kept per host name whatever the port: reach srv1 as
+	// localhost so that each fake gets its own session cookie.
+	_, port1, errSplit := net.SplitHostPort(srv1.Host())
+	if errSplit != nil {
+		t.Fatal(errSplit)
+	}
+	opt := srv0.ClientOptions()
+	opt.Hosts = []string{srv0.Host(), net.JoinHostPort("localhost", port1)}
+	opt.ServerName = "127.0.0.1"
+	opt.HostPolicy = nx.HostRoundRobin
+
+	c := login(t, srv0, opt)
+	defer c.Logout()
+
+	for i := 0; i < 4; i++ {
+		if _, errGet := c.GetL2BD(""); errGet != nil {
+			t.Fatalf("GetL2BD: %v", errGet)
+		}
+	}
+	for i, srv := range []*nxtest.Server{srv0, srv1} {
+		if got := count(srv, "POST", "/api/aaaLogin.json"); got != 1 {
+			t.Errorf("host %d: %d logins, want 1", i, got)
+		}
+	}
+
+	// Concurrent requests refused with the same expired token log in once per host.
+	srv0.ExpireSessions()
+	srv1.ExpireSessions()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errGet := c.GetL2BD("")
+			errs <- errGet
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for errGet := range errs {
+		if errGet != nil {
+			t.Errorf("GetL2BD after sessions expired: %v", errGet)
+		}
+	}
+	for i, srv := range []*nxtest.Server{srv0, srv1} {
+		if got := count(srv, "POST", "/api/aaaLogin.json"); got != 2 {
+			t.Errorf("host %d after sessions expired: %d logins, want 2", i, got)
+		}
+	}
+
+	// Logout closes the session of both hosts.
+	c.Logout()
+	for i, srv := range []*nxtest.Server{srv0, srv1} {
+		if got := count(srv, "POST", "/api/aaaLogout.json"); got != 1 {
+			t.Errorf("host %d: %d logouts, want 1", i, got)
+		}
+	}
+}
+
+func TestAddVlan(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	c := login(t, srv, srv.ClientOptions())
+	defer c.Logout()
+
+	if errAdd := c.AddVlan("10", "5010"); errAdd != nil {
+		t.Fatalf("AddVlan: %v", errAdd)
+	}
+	web := "web"
+	if errAdd := c.AddVlanWithOptions("20", nx.VlanOptions{Name: &web, AdminSt: nx.VlanSuspend}); errAdd != nil {
+		t.Fatalf("AddVlanWithOptions: %v", errAdd)
+	}
+
+	vlans, errGet := c.GetL2BD("")
+	if errGet != nil {
+		t.Fatalf("GetL2BD: %v", errGet)
+	}
+	if len(vlans) != 2 {
+		t.Fatalf("GetL2BD: got %+v, want vlans 10 and 20", vlans)
+	}
+	byID := map[int]nx.L2BD{}
+	for _, v := range vlans {
+		byID[v.ID] = v
+	}
+	if v := byID[10]; v.Dn != "sys/bd/bd-[vlan-10]" || v.FabEncap != "vlan-10" || v.Vni != 5010 || v.AdminSt != nx.VlanActive {
+		t.Errorf("vlan 10: got %+v", v)
+	}
+	if v := byID[20]; v.Name != "web" || v.AdminSt != nx.VlanSuspend || v.Vni != 0 {
+		t.Errorf("vlan 20: got %+v", v)
+	}
+
+	one, errGet := c.GetL2BD("20")
+	if errGet != nil || len(one) != 1 || one[0].ID != 20 {
+		t.Errorf("GetL2BD(20): got %+v, %v", one, errGet)
+	}
+
+	// A nil Name leaves the name unchanged, an empty one clears it.
+	if errUpdate := c.UpdateVlan("20", nx.VlanOptions{AdminSt: nx.VlanActive}); errUpdate != nil {
+		t.Fatalf("UpdateVlan: %v", errUpdate)
+	}
+	if _, attrs, _ := srv.MO("sys/bd/bd-[vlan-20]"); attrs["name"] != "web" || attrs["adminSt"] != nx.VlanActive {
+		t.Errorf("UpdateVlan without name: got %v", attrs)
+	}
+	noName := ""
+	if errUpdate := c.UpdateVlan("20", nx.VlanOptions{Name: &noName}); errUpdate != nil {
+		t.Fatalf("UpdateVlan clearing name: %v", errUpdate)
+	}
+	if _, attrs, _ := srv.MO("sys/bd/bd-[vlan-20]"); attrs["name"] != "" {
+		t.Errorf("UpdateVlan clearing name: name %q", attrs["name"])
+	}
+
+	if errAdd := c.AddVlan("4095", ""); errAdd == nil {
+		t.Errorf("AddVlan(4095): got no error")
+	}
+
+	if errDel := c.DeleteVlan("10"); errDel != nil {
+		t.Fatalf("DeleteVlan: %v", errDel)
+	}
+	if _, _, found := srv.MO("sys/bd/bd-[vlan-10]"); found {
+		t.Errorf("vlan 10 still present after DeleteVlan")
+	}
+}
+
+func TestAllowedVlans(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	srv.AddMO("sys/intf/phys-[eth1/3]", "l1PhysIf", map[string]string{"id": "eth1/3"})
+
+	c := login(t, srv, srv.ClientOptions())
+	defer c.Logout()
+
+	trunkVlans := func(dn string) string {
+		_, attrs, found := srv.MO(dn)
+		if !found {
+			t.Fatalf("%s not found", dn)
+		}
+		return attrs["trunkVlans"]
+	}
+
+	steps := []struct {
+		name string
+		do   func() error
+		want string
+	}{
+		{"set", func() error { return c.SetAllowedVlans("ethernet:1/3", nx.VLANSet{30, 10, 11, 12}) }, "10-12,30"},
+		{"add", func() error { return c.AddAllowedVlans("ethernet:1/3", nx.VLANSet{20, 13}) }, "10-13,20,30"},
+		{"remove", func() error { return c.RemoveAllowedVlans("ethernet:1/3", nx.VLANSet{30, 10}) }, "11-13,20"},
+		{"remove missing", func() error { return c.RemoveAllowedVlans("ethernet:1/3", nx.VLANSet{100}) }, "11-13,20"},
+		{"set empty", func() error { return c.SetAllowedVlans("ethernet:1/3", nil) }, ""},
+	}
+	for _, s := range steps {
+		if errStep := s.do(); errStep != nil {
+			t.Fatalf("%s: %v", s.name, errStep)
+		}
+		if got := trunkVlans("sys/intf/phys-[eth1/3]"); got != s.want {
+			t.Errorf("%s: trunkVlans = %q, want %q", s.name, got, s.want)
+		}
+	}
+
+	// Updates are applied by the switch, without reading the port first,
+	// so changes made meanwhile by another client are kept.
+	other := login(t, srv, srv.ClientOptions())
+	defer other.Logout()
+	before := len(srv.Requests())
+	if errAdd := c.AddAllowedVlans("ethernet:1/3", nx.VLANSet{40, 41}); errAdd != nil {
+		t.Fatalf("AddAllowedVlans: %v", errAdd)
+	}
+	if errAdd := other.AddAllowedVlans("ethernet:1/3", nx.VLANSet{50}); errAdd != nil {
+		t.Fatalf("AddAllowedVlans from other client: %v", errAdd)
+	}
+	if errRemove := c.RemoveAllowedVlans("ethernet:1/3", nx.VLANSet{41}); errRemove != nil {
+		t.Fatalf("RemoveAllowedVlans: %v", errRemove)
+	}
+	if got := trunkVlans("sys/intf/phys-[eth1/3]"); got != "40,50" {
+		t.Errorf("concurrent updates: trunkVlans = %q, want 40,50", got)
+	}
+	var bodies []string
+	for _, r := range srv.Requests()[before:] {
+		if r.Method == "GET" {
+			t.Errorf("update read the port: %s %s", r.Method, r.URI)
+		}
+		if r.Method == "POST" && strings.Contains(r.Body, "trunkVlans") {
+			bodies = append(bodies, r.Body)
+		}
+	}
+	for i, want := range []string{`"trunkVlans":"+40-41"`, `"trunkVlans":"+50"`, `"trunkVlans":"-41"`} {
+		if i >= len(bodies) || !strings.Contains(bodies[i], want) {
+			t.Errorf("update %d: want body with %s, got %v", i, want, bodies)
+		}
+	}
+
+	if errAdd := c.AddAllowedVlans("ethernet:1/3", nx.VLANSet{4095}); errAdd == nil {
+		t.Errorf("AddAllowedVlans(4095): got no error")
+	}
+}
+
+func TestBulkVlans(t *testing.T) {
+	srv := nxtest.NewServer()
+	defer srv.Close()
+
+	opt := srv.ClientOptions()
+	opt.BulkChunkSize = 4
+	c := login(t, srv, opt)
+	defer c.Logout()
+
+	vlans, errParse := nx.ParseVLANSet("100-109")
+	if errParse != nil {
+		t.Fatal(errParse)
+	}
+
+	posts := count(srv, "POST", "/api/mo.json")
+	bulk := "bulk"
+	report, errAdd := c.AddVlans(vlans, nx.VlanOptions{Name: &bulk})
+	if errAdd != nil {
+		t.Fatalf("AddVlans: %v", errAdd)
+	}
+	if len(report) != 3 || report[0].Vlans.String() != "100-103" || report[2].Vlans.String() != "108-109" {
+		t.Errorf("AddVlans report: got %+v, want 3 chunks", report)
+	}
+	if got := count(srv, "POST", "/api/mo.json") - posts; got != 3 {
+		t.Errorf("AddVlans: %d posts, want 3", got)
+	}
+
+	got, errGet := c.GetL2BD("")
+	if errGet != nil {
+		t.Fatalf("GetL2BD: %v", errGet)
+	}
+	if len(got) != 10 {
+		t.Errorf("after AddVlans: got %d vlans, want 10", len(got))
+	}
+	for _, v := range got {
+		if v.Name != "bulk" {
+			t.Errorf("vlan %d: name %q, want bulk", v.ID, v.Name)
+		}
+	}
+
+	report, errDel := c.DeleteVlans(nx.VLANSet{109, 100, 105, 100})
+	if errDel != nil {
+		t.Fatalf("DeleteVlans: %v", errDel)
+	}
+	if len(report) != 1 || report[0].Vlans.String() != "100,105,109" {
+		t.Errorf("DeleteVlans report: got %+v", report)
+	}
+
+	got, _ = c.GetL2BD("")
+	var ids []int
+	for _, v := range got {
+		ids = append(ids, v.ID)
+	}
+	if s := nx.NewVLANSet(ids...).String(); s != "101-104,106-108" {
+		t.Errorf("after DeleteVlans: got vlans %s, want 101-104,106-108", s)
+	}
+
+	if _, errEmpty := c.AddVlans(nil, nx.VlanOptions{}); errEmpty == nil {
+		t.Errorf("AddVlans(nil): got no error")
+	}
+}
@@ -0,0 +1,263 @@
+package nxtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/caboucha/nxgo/nx"
+)
+
+// rnFormats maps object classes to their relative name. {attr} is replaced
+// by the value of attribute attr.
+var rnFormats = map[string]string{
+	"topSystem":       "sys",
+	"interfaceEntity": "intf",
+	"l1PhysIf":        "phys-[{id}]",
+	"pcAggrIf":        "aggr-[{id}]",
+	"pcRsMbrIfs":      "rsmbrIfs-[{tDn}]",
+	"ethpmPhysIf":     "phys",
+	"ethpmAggrIf":     "aggrif",
+	"rmonIfIn":        "dbgIfIn",
+	"rmonIfOut":       "dbgIfOut",
+	"bdEntity":        "bd",
+	"l2BD":            "bd-[{fabEncap}]",
+	"stpEntity":       "stp",
+	"stpInst":         "inst",
+	"stpIf":           "if-[{id}]",
+	"vpcEntity":       "vpc",
+	"vpcInst":         "inst",
+	"vpcDom":          "dom",
+	"vpcIf":           "if-{id}",
+	"vpcRsVpcConf":    "rsvpcConf",
+	"vpcKeepalive":    "keepalive",
+	"vpcPeerLink":     "peerlink",
+	"lacpEntity":      "lacp",
+	"lacpInst":        "inst",
+	"lacpIf":          "if-[{id}]",
+}
+
+// defaults holds the attributes the switch fills in when an object is created.
+var defaults = map[string]map[string]string{
+	"l1PhysIf": {"adminSt": "up", "mode": "access", "layer": "Layer2", "nativeVlan": "vlan-1",
+		"accessVlan": "vlan-1", "trunkVlans": "1-4094", "mtu": "1500", "speed": "auto",
+		"duplex": "auto", "autoNeg": "on", "descr": ""},
+	"pcAggrIf": {"adminSt": "up", "mode": "access", "layer": "Layer2", "nativeVlan": "vlan-1",
+		"accessVlan": "vlan-1", "trunkVlans": "1-4094", "mtu": "1500", "speed": "auto",
+		"duplex": "auto", "autoNeg": "on", "descr": "", "pcMode": "on",
+		"ctrl": "fast-sel-hot-stdby,graceful-conv,susp-individual"},
+	"l2BD":       {"accEncap": "unknown", "adminSt": "active", "operSt": "down", "mode": "CE", "name": ""},
+	"pcRsMbrIfs": {"channelingSt": "channeled", "isMbrUp": "yes"},
+	"stpIf":      {"mode": "default"},
+}
+
+// mo is a managed object of the in-memory tree.
+type mo struct {
+	class    string
+	rn       string
+	dn       string
+	attrs    map[string]string
+	children map[string]*mo
+	parent   *mo
+}
+
+func newMo(parent *mo, class, rn string) *mo {
+	dn := rn
+	if parent != nil && parent.dn != "" {
+		dn = parent.dn + "/" + rn
+	}
+	m := &mo{class: class, rn: rn, dn: dn, attrs: map[string]string{}, children: map[string]*mo{}, parent: parent}
+	for k, v := range defaults[class] {
+		m.attrs[k] = v
+	}
+	m.attrs["dn"] = dn
+	m.attrs["rn"] = rn
+	return m
+}
+
+// rnOf computes the relative name of an object of class with attributes attrs.
+func rnOf(class string, attrs map[string]string) (string, error) {
+	if rn := attrs["rn"]; rn != "" {
+		return rn, nil
+	}
+	if dn := attrs["dn"]; dn != "" {
+		parts := splitDn(dn)
+		return parts[len(parts)-1], nil
+	}
+	format, found := rnFormats[class]
+	if !found {
+		return "", fmt.Errorf("unknown class %s: missing rn", class)
+	}
+	for {
+		i := strings.Index(format, "{")
+		if i < 0 {
+			return format, nil
+		}
+		j := strings.Index(format[i:], "}") + i
+		name := format[i+1 : j]
+		value := attrs[name]
+		if value == "" {
+			return "", fmt.Errorf("class %s: missing naming attribute %s", class, name)
+		}
+		format = format[:i] + value + format[j+1:]
+	}
+}
+
+// splitDn splits a dn into relative names, ignoring slashes within brackets.
+// Ex: sys/intf/aggr-[po5]/rsmbrIfs-[sys/intf/phys-[eth1/3]] results in
+// sys, intf, aggr-[po5], rsmbrIfs-[sys/intf/phys-[eth1/3]]
+func splitDn(dn string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(dn); i++ {
+		switch dn[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '/':
+			if depth == 0 {
+				parts = append(parts, dn[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, dn[start:])
+}
+
+// lookup finds the object with distinguished name dn.
+func (root *mo) lookup(dn string) *mo {
+	dn = strings.Trim(dn, "/")
+	node := root
+	for _, rn := range splitDn(dn) {
+		node = node.children[rn]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// merge applies the objects of a posted body below node.
+func (node *mo) merge(class string, attrs map[string]string, children []map[string]body) error {
+	rn, errRn := rnOf(class, attrs)
+	if errRn != nil {
+		return errRn
+	}
+
+	target := node.children[rn]
+
+	if attrs["status"] == "deleted" {
+		delete(node.children, rn)
+		return nil
+	}
+
+	if target == nil {
+		target = newMo(node, class, rn)
+		node.children[rn] = target
+		onCreate(target, attrs)
+	}
+
+	for k, v := range attrs {
+		switch k {
+		case "dn", "rn", "status":
+			continue
+		case "trunkVlans":
+			v = mergeVlans(target.attrs[k], v)
+		}
+		target.attrs[k] = v
+	}
+
+	for _, child := range children {
+		for childClass, b := range child {
+			if errMerge := target.merge(childClass, b.attrs(), b.Children); errMerge != nil {
+				return errMerge
+			}
+		}
+	}
+
+	return nil
+}
+
+// onCreate fills in the attributes the switch derives from the naming attributes.
+func onCreate(m *mo, attrs map[string]string) {
+	switch m.class {
+	case "l2BD":
+		id := strings.TrimPrefix(attrs["fabEncap"], "vlan-")
+		m.attrs["id"] = id
+		var n int
+		fmt.Sscanf(id, "%d", &n)
+		m.attrs["BdOperName"] = fmt.Sprintf("VLAN%04d", n)
+	}
+}
+
+// mergeVlans applies NX-API trunk vlan syntax: "+list" adds to and "-list"
+// removes from the current vlans, anything else replaces them.
+func mergeVlans(cur, update string) string {
+	if update == "" || (update[0] != '+' && update[0] != '-') {
+		return update
+	}
+	curSet, _ := nx.ParseVLANSet(cur)
+	updSet, errParse := nx.ParseVLANSet(update[1:])
+	if errParse != nil {
+		return cur
+	}
+	if update[0] == '+' {
+		return curSet.Union(updSet).String()
+	}
+	return curSet.Difference(updSet).String()
+}
+
+// walk calls fn for node and all its descendants, in dn order.
+func (node *mo) walk(fn func(*mo)) {
+	fn(node)
+	for _, child := range node.sortedChildren() {
+		child.walk(fn)
+	}
+}
+
+func (node *mo) sortedChildren() []*mo {
+	list := make([]*mo, 0, len(node.children))
+	for _, child := range node.children {
+		list = append(list, child)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].rn < list[j].rn })
+	return list
+}
+
+// encode returns the imdata form of node. depth is the number of child
+// levels to include: 0 for none, -1 for the full subtree.
+func (node *mo) encode(depth int) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	for k, v := range node.attrs {
+		attrs[k] = v
+	}
+	obj := map[string]interface{}{"attributes": attrs}
+	if depth != 0 && len(node.children) > 0 {
+		var children []interface{}
+		for _, child := range node.sortedChildren() {
+			children = append(children, child.encode(depth-1))
+		}
+		obj["children"] = children
+	}
+	return map[string]interface{}{node.class: obj}
+}
+
+// body is the json form of a posted object.
+type body struct {
+	Attributes map[string]interface{} `json:"attributes"`
+	Children   []map[string]body      `json:"children"`
+}
+
+// attrs returns the object attributes as strings, as the switch stores them.
+func (b body) attrs() map[string]string {
+	attrs := make(map[string]string, len(b.Attributes))
+	for k, v := range b.Attributes {
+		if s, isStr := v.(string); isStr {
+			attrs[k] = s
+			continue
+		}
+		attrs[k] = fmt.Sprint(v)
+	}
+	return attrs
+}
@@ -3,6 +3,7 @@ package main
 import (
         "log"
         "os"
+        "strconv"
 
         "github.com/caboucha/nxgo/nx"
 )
@@ -10,7 +11,7 @@ import (
 func main() {
 
         if len(os.Args) < 3 {
-                log.Fatalf("usage: %s add|remove|replace|show [ethernet|port-channel][:id] [allowed-vlan] [native-vlan] ",
+                log.Fatalf("usage: %s add|remove|replace|access|show [ethernet|port-channel][:id] [allowed-vlan|access-vlan] [native-vlan] ",
                            os.Args[0])
                 log.Fatalf("       set native-vlan to None to remove existing config.")
         }
@@ -38,28 +39,33 @@ func main() {
         }
 
         // Print the legend
-        log.Printf("ID\t\tNative\tTrunk\tMode\tState\tDescr\n")
+        log.Printf("ID\t\tNative\tTrunk\tAccess\tMode\tState\tDescr\n")
         for _, r := range resp {
                 tvlan := r["trunkVlans"]
                 if r["trunkVlans"] == "" {
                     tvlan = "None"
                 }
-                log.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", r["id"], r["nativeVlan"],
-                           tvlan, r["mode"], r["adminSt"], r["descr"])
+                log.Printf("%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r["id"], r["nativeVlan"],
+                           tvlan, r["accessVlan"], r["mode"], r["adminSt"], r["descr"])
         }
 
 }
 
 func execute(a *nx.Client, cmd string, ifName string,
 allowed string, native string) {
+        var err error
+
         switch cmd {
-        case "add":
-                allowed = "+" + allowed
-        case "replace":
-        case "remove":
-                allowed = "-" + allowed
-                if native != "" {
-                    native = "None"
+        case "add", "replace", "remove":
+                err = trunk(a, cmd, ifName, allowed, native)
+        case "access":
+                if allowed == "None" {
+                        err = a.ClearAccessVlan(ifName)
+                } else {
+                        err = a.SetAccessVlan(ifName, allowed)
+                }
+                if err == nil {
+                        log.Printf("Access Vlan %s set for interface %s\n", allowed, ifName)
                 }
         case "show":
                 return
@@ -68,21 +74,56 @@ allowed string, native string) {
                 return
         }
 
- 
-        // Note client package uses add naming instead of create.
-        err := a.AddTrunkVlan(ifName,
-                              allowed, native)
         if err != nil {
+                log.Printf("%s error: %v", cmd, err)
                 os.Exit(1)
         }
+}
+
+func trunk(a *nx.Client, cmd string, ifName string,
+allowed string, native string) error {
+
+        vlans, err := nx.ParseVLANSet(allowed)
+        if err != nil {
+                return err
+        }
+
+        switch cmd {
+        case "add":
+                err = a.AddAllowedVlans(ifName, vlans)
+        case "replace":
+                err = a.SetAllowedVlans(ifName, vlans)
+        case "remove":
+                err = a.RemoveAllowedVlans(ifName, vlans)
+        }
+        if err != nil {
+                return err
+        }
+
+        switch {
+        case native == "":
+        case native == "None" || cmd == "remove":
+                err = a.ClearNativeVlan(ifName)
+        default:
+                var vlan int
+                vlan, err = strconv.Atoi(native)
+                if err == nil {
+                        err = a.SetNativeVlan(ifName, vlan)
+                }
+        }
+        if err != nil {
+                return err
+        }
 
         if native != "" {
             log.Printf("Trunk Vlan %s native %s %s for interface %s\n",
                        allowed, native, cmd, ifName)
         } else {
-            log.Printf("Trunk Vlan %s %s for interface.\n",
+            log.Printf("Trunk Vlan %s %s for interface %s\n",
                        allowed, cmd, ifName)
         }
+
+        return nil
 }
 
 func login(debug bool) *nx.Client {
@@ -29,7 +29,7 @@ func main() {
         // add/del vlans
         execute(a, cmd, vlanid, vni)
 
-        resp, getErr := a.GetVlan(vlanid)
+        resp, getErr := a.GetL2BD(vlanid)
         if getErr != nil {
                 log.Printf("could not get vlan: %v", getErr)
                 return
@@ -38,15 +38,15 @@ func main() {
         // Print the legend
         log.Printf("\tID\t\tVNI\t\tAdmin State\tOperState\tName\n")
         for _, r := range resp {
-                if r["BdOperName"] == "" {
+                if r.OperName == "" {
                     continue
                 }
-                if r["accEncap"] == "unknown" {
-                    log.Printf("\t%s\t\t%s\t\t%s\t\t%s\t\t%s\n", r["id"], r["accEncap"],
-                               r["adminSt"], r["operSt"], r["BdOperName"])
+                if r.AccEncap == "unknown" {
+                    log.Printf("\t%d\t\t%s\t\t%s\t\t%s\t\t%s\n", r.ID, r.AccEncap,
+                               r.AdminSt, r.OperSt, r.OperName)
                 } else {
-                    log.Printf("\t%s\t\t%s\t%s\t\t%s\t\t%s\n", r["id"], r["accEncap"],
-                               r["adminSt"], r["operSt"], r["BdOperName"])
+                    log.Printf("\t%d\t\t%s\t%s\t\t%s\t\t%s\n", r.ID, r.AccEncap,
+                               r.AdminSt, r.OperSt, r.OperName)
                 }
         }
 